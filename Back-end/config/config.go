@@ -3,8 +3,11 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 
 	"product-manager/drivers/databases"
+	"product-manager/drivers/storage"
+	"product-manager/usecases"
 	"github.com/joho/godotenv"
 )
 
@@ -30,3 +33,19 @@ func InitConfigDB() databases.Config {
 	}
 }
 
+func InitConfigStorage() storage.Config {
+	return storage.Config{
+		STORAGE_DRIVER:     os.Getenv("STORAGE_DRIVER"),
+		STORAGE_LOCAL_ROOT: os.Getenv("STORAGE_LOCAL_ROOT"),
+		STORAGE_PUBLIC_URL: os.Getenv("STORAGE_PUBLIC_URL"),
+	}
+}
+
+// InitConfigTax reads PRICES_INCLUDE_TAX, taking stored prices as net when
+// it is unset.
+func InitConfigTax() usecases.TaxConfig {
+	includeTax, _ := strconv.ParseBool(os.Getenv("PRICES_INCLUDE_TAX"))
+	return usecases.TaxConfig{
+		PRICES_INCLUDE_TAX: includeTax,
+	}
+}
@@ -3,10 +3,12 @@ package messages
 const (
 	// Database
 	FAILED_CONNECT_DB = "failed connect to database"
+	FAILED_MIGRATE_DB = "failed migrate database"
 
 	// Auth
 	INVALID_TOKEN = "invalid token"
 	UNAUTHORIZED  = "unauthorized"
+	FORBIDDEN     = "you do not have permission to do this"
 
 	// Password
 	FAILED_HASHING_PASSWORD = "failed hashing password"
@@ -19,16 +21,125 @@ const (
 	// Validation
 	MISMATCH_DATA_TYPE   = "mismatch data type"
 	INVALID_REQUEST_DATA = "invalid request data"
+	INVALID_CURSOR       = "invalid or expired cursor"
+	INVALID_FACET        = "unknown facet"
+	INVALID_SORT_FIELD   = "invalid sort field"
+	INVALID_FILTER_FIELD = "invalid filter field"
+	INVALID_FILTER_OP    = "invalid filter operator"
+	INVALID_FILTER_VALUE = "invalid filter value"
 
 	// Product
-	PRODUCT_NOT_FOUND         = "product not found"
-	PRODUCT_NAME_REQUIRED     = "product name is required"
-	PRODUCT_CATEGORY_REQUIRED = "product category is required"
-	PRODUCT_PRICE_REQUIRED    = "product price is required"
-	INVALID_PRODUCT_ID        = "invalid product ID"
-	PRODUCT_ALREADY_EXISTS    = "product already exists"
-    INTERNAL_SERVER_ERROR      = "Internal server error"
+	PRODUCT_NOT_FOUND          = "product not found"
+	PRODUCT_NAME_REQUIRED      = "product name is required"
+	PRODUCT_CATEGORY_REQUIRED  = "product category is required"
+	PRODUCT_PRICE_REQUIRED     = "product price is required"
+	INVALID_PRODUCT_ID         = "invalid product ID"
+	PRODUCT_ALREADY_EXISTS     = "product already exists"
+	PRODUCT_NOT_IN_TRASH       = "product is not in trash"
+	PRODUCT_VERSION_CONFLICT   = "product has been modified by someone else"
+	BULK_OPERATION_ROLLED_BACK = "bulk operation rolled back"
 
+	// Category
+	CATEGORY_NOT_FOUND      = "category not found"
+	CATEGORY_NAME_REQUIRED  = "category name is required"
+	INVALID_CATEGORY_SLUG   = "category slug may only contain lowercase letters, digits and single hyphens"
+	INVALID_CATEGORY_ID     = "invalid category ID"
+	CATEGORY_ALREADY_EXISTS = "category already exists"
+	CATEGORY_IN_USE         = "category is still assigned to products"
+	CATEGORY_HAS_CHILDREN   = "category still has subcategories"
+	CATEGORY_CYCLE          = "category cannot be moved under itself or its descendants"
+
+	// Variant
+	VARIANT_NOT_FOUND             = "variant not found"
+	INVALID_VARIANT_ID            = "invalid variant ID"
+	VARIANT_SKU_REQUIRED          = "variant SKU is required"
+	VARIANT_OPTIONS_REQUIRED      = "variant needs at least one option"
+	VARIANT_SKU_ALREADY_EXISTS    = "variant SKU already exists"
+	VARIANT_OPTIONS_ALREADY_EXIST = "product already has a variant with these options"
+	VARIANT_REQUIRED              = "product has variants, so stock moves by variant_id"
+
+	// Image
+	IMAGE_NOT_FOUND        = "image not found"
+	INVALID_IMAGE_ID       = "invalid image ID"
+	IMAGE_REQUIRED         = "image file is required"
+	IMAGE_TOO_LARGE        = "image exceeds the size limit"
+	UNSUPPORTED_IMAGE_TYPE = "image must be a JPEG, PNG or GIF"
+	INVALID_IMAGE          = "image could not be decoded"
+
+	// Stock
+	INVALID_STOCK_MOVEMENT_TYPE = "invalid stock movement type"
+	INVALID_STOCK_QUANTITY      = "invalid stock quantity"
+	INSUFFICIENT_STOCK          = "insufficient stock"
+
+	// Price
+	PRICE_NOT_FOUND           = "price not found"
+	INVALID_PRICE_ID          = "invalid price ID"
+	INVALID_PRICE_DATE        = "date must be RFC 3339 or YYYY-MM-DD"
+	PRICE_START_NOT_IN_FUTURE = "effective_from must be in the future"
+	INVALID_PRICE_PERIOD      = "effective_to must be after effective_from"
+	PRICE_ALREADY_EFFECTIVE   = "price has already taken effect"
+
+	// Currency
+	UNSUPPORTED_CURRENCY     = "unsupported currency"
+	INVALID_EXCHANGE_RATE    = "exchange rate must be a positive decimal with at most 10 digits either side of the point"
+	EXCHANGE_RATE_NOT_FOUND  = "exchange rate not found"
+	SAME_CURRENCY_RATE       = "exchange rate needs two different currencies"
+	CURRENCY_PRICE_NOT_FOUND = "currency price not found"
+	BASE_CURRENCY_PRICE      = "prices in the base currency are set on the product"
+	NOT_BASE_CURRENCY        = "catalog prices and costs must be in the base currency"
+	INVALID_AMOUNT           = "amount must be a whole number of the currency's minor unit"
+
+	// Tax
+	TAX_CLASS_NOT_FOUND        = "tax class not found"
+	INVALID_TAX_CLASS_ID       = "invalid tax class ID"
+	TAX_CLASS_ALREADY_EXISTS   = "tax class already exists"
+	TAX_CLASS_IN_USE           = "tax class is still assigned to products"
+	TAX_RATE_NOT_FOUND         = "tax rate not found"
+	INVALID_TAX_RATE_ID        = "invalid tax rate ID"
+	TAX_RATE_ALREADY_EXISTS    = "tax class already has a rate from that time"
+	TAX_RATE_ALREADY_EFFECTIVE = "tax rate has already taken effect"
+
+	// Cost
+	UNIT_COST_NOT_ALLOWED = "unit_cost can only be given for receipts"
+	INVALID_MARGIN_LEVEL  = "level must be product or category"
+	INVALID_MARGIN_FILTER = "min_margin and max_margin must be numbers"
+
+	INVALID_VALUATION_METHOD = "method must be fifo or weighted_average"
+	INVALID_VALUATION_DATE   = "as_of must be an RFC 3339 time or a YYYY-MM-DD date"
+
+	// Promotion
+	PROMOTION_NOT_FOUND           = "promotion not found"
+	INVALID_PROMOTION_ID          = "invalid promotion ID"
+	INVALID_PROMOTION_VALUE       = "percentage promotions take a value of 1 to 10000 basis points and fixed ones an amount above zero"
+	INVALID_PROMOTION_PERIOD      = "ends_at must be after starts_at"
+	PROMOTION_TARGETS_REQUIRED    = "promotions scoped to products or categories need target_ids"
+	PROMOTION_TARGETS_NOT_ALLOWED = "promotions scoped to all products take no target_ids"
+	INVALID_PROMOTION_STATUS      = "status must be scheduled, active or ended"
+
+	// Webhook
+	WEBHOOK_NOT_FOUND           = "webhook not found"
+	INVALID_WEBHOOK_ID          = "invalid webhook ID"
+	INVALID_WEBHOOK_URL         = "webhook URL must be an absolute http or https URL"
+	INVALID_WEBHOOK_EVENT       = "unknown webhook event"
+	WEBHOOK_DELIVERY_NOT_FOUND  = "webhook delivery not found"
+	INVALID_WEBHOOK_DELIVERY_ID = "invalid webhook delivery ID"
+
+	// Import
+	IMPORT_FILE_REQUIRED  = "import file is required"
+	IMPORT_MISSING_COLUMN = "import file is missing a required column"
+	IMPORT_INVALID_CSV    = "import file is not valid CSV"
+	IMPORT_DUPLICATE_NAME = "duplicate product name in file"
+	PRODUCT_PRICE_INVALID = "product price must be a positive whole number"
+	PRODUCT_STOCK_INVALID = "product stock must be a non-negative whole number"
+
+	// Export
+	INVALID_EXPORT_FORMAT = "export format must be csv, xlsx or ndjson"
+
+	// Audit
+	INVALID_ADMIN_ID      = "invalid admin ID"
+	INVALID_AUDIT_ACTION  = "invalid audit action"
+	INVALID_DATE_RANGE    = "invalid date range"
+	INTERNAL_SERVER_ERROR = "Internal server error"
 
 	FAILED_GET_PRODUCTS_ALL = "failed get products all"
 )
@@ -1,14 +1,86 @@
 package messages
 
 const (
-	SUCCESS_REGISTER_ADMIN      = "Admin registered successfully"
-	SUCCESS_LOGIN_ADMIN         = "Admin logged in successfully"
-
-	
-	SUCCESS_CREATE_PRODUCT      = "Product created successfully"
-    SUCCESS_GET_PRODUCT         = "Product retrieved successfully"
-    SUCCESS_GET_PRODUCTS_ALL    = "Products retrieved successfully"
-    SUCCESS_UPDATE_PRODUCT      = "Product updated successfully"
-    SUCCESS_DELETE_PRODUCT      = "Product deleted successfully"
-	
-)
\ No newline at end of file
+	SUCCESS_REGISTER_ADMIN = "Admin registered successfully"
+	SUCCESS_LOGIN_ADMIN    = "Admin logged in successfully"
+
+	SUCCESS_CREATE_PRODUCT       = "Product created successfully"
+	SUCCESS_GET_PRODUCT          = "Product retrieved successfully"
+	SUCCESS_GET_PRODUCTS_ALL     = "Products retrieved successfully"
+	SUCCESS_UPDATE_PRODUCT       = "Product updated successfully"
+	SUCCESS_DELETE_PRODUCT       = "Product deleted successfully"
+	SUCCESS_GET_TRASHED_PRODUCTS = "Trashed products retrieved successfully"
+	SUCCESS_RESTORE_PRODUCT      = "Product restored successfully"
+	SUCCESS_PURGE_PRODUCT        = "Product permanently deleted successfully"
+	SUCCESS_BULK_PRODUCTS        = "Bulk product operations completed"
+	SUCCESS_IMPORT_PRODUCTS      = "Products imported successfully"
+	SUCCESS_VALIDATE_IMPORT      = "Import file validated successfully"
+
+	SUCCESS_GET_PRODUCT_HISTORY = "Product history retrieved successfully"
+	SUCCESS_GET_PRODUCT_AUDITS  = "Product audits retrieved successfully"
+
+	SUCCESS_CREATE_CATEGORY   = "Category created successfully"
+	SUCCESS_GET_CATEGORY      = "Category retrieved successfully"
+	SUCCESS_GET_CATEGORIES    = "Categories retrieved successfully"
+	SUCCESS_UPDATE_CATEGORY   = "Category updated successfully"
+	SUCCESS_DELETE_CATEGORY   = "Category deleted successfully"
+	SUCCESS_MOVE_CATEGORY     = "Category moved successfully"
+	SUCCESS_GET_CATEGORY_TREE = "Category tree retrieved successfully"
+
+	SUCCESS_CREATE_VARIANT = "Variant created successfully"
+	SUCCESS_GET_VARIANT    = "Variant retrieved successfully"
+	SUCCESS_GET_VARIANTS   = "Variants retrieved successfully"
+	SUCCESS_UPDATE_VARIANT = "Variant updated successfully"
+	SUCCESS_DELETE_VARIANT = "Variant deleted successfully"
+
+	SUCCESS_UPLOAD_IMAGE = "Image uploaded successfully"
+	SUCCESS_GET_IMAGES   = "Images retrieved successfully"
+	SUCCESS_UPDATE_IMAGE = "Image updated successfully"
+	SUCCESS_DELETE_IMAGE = "Image deleted successfully"
+
+	SUCCESS_CREATE_WEBHOOK       = "Webhook created successfully"
+	SUCCESS_GET_WEBHOOK          = "Webhook retrieved successfully"
+	SUCCESS_GET_WEBHOOKS         = "Webhooks retrieved successfully"
+	SUCCESS_UPDATE_WEBHOOK       = "Webhook updated successfully"
+	SUCCESS_DELETE_WEBHOOK       = "Webhook deleted successfully"
+	SUCCESS_GET_WEBHOOK_DELIVERY = "Webhook delivery retrieved successfully"
+	SUCCESS_GET_WEBHOOK_LOG      = "Webhook deliveries retrieved successfully"
+	SUCCESS_REDELIVER_WEBHOOK    = "Webhook redelivery scheduled successfully"
+
+	SUCCESS_CREATE_STOCK_MOVEMENT = "Stock movement recorded successfully"
+	SUCCESS_GET_STOCK_MOVEMENTS   = "Stock movements retrieved successfully"
+	SUCCESS_RECONCILE_STOCK       = "Stock reconciliation retrieved successfully"
+	SUCCESS_GET_LOW_STOCK         = "Low stock products retrieved successfully"
+
+	SUCCESS_SCHEDULE_PRICE = "Price scheduled successfully"
+	SUCCESS_GET_PRICES     = "Price history retrieved successfully"
+	SUCCESS_GET_PRICE      = "Price retrieved successfully"
+	SUCCESS_CANCEL_PRICE   = "Scheduled price cancelled successfully"
+
+	SUCCESS_GET_EXCHANGE_RATES    = "Exchange rates retrieved successfully"
+	SUCCESS_SET_EXCHANGE_RATE     = "Exchange rate saved successfully"
+	SUCCESS_DELETE_EXCHANGE_RATE  = "Exchange rate deleted successfully"
+	SUCCESS_GET_CURRENCY_PRICES   = "Currency prices retrieved successfully"
+	SUCCESS_SET_CURRENCY_PRICE    = "Currency price saved successfully"
+	SUCCESS_DELETE_CURRENCY_PRICE = "Currency price deleted successfully"
+
+	SUCCESS_CREATE_TAX_CLASS = "Tax class created successfully"
+	SUCCESS_GET_TAX_CLASS    = "Tax class retrieved successfully"
+	SUCCESS_GET_TAX_CLASSES  = "Tax classes retrieved successfully"
+	SUCCESS_UPDATE_TAX_CLASS = "Tax class updated successfully"
+	SUCCESS_DELETE_TAX_CLASS = "Tax class deleted successfully"
+	SUCCESS_CREATE_TAX_RATE  = "Tax rate created successfully"
+	SUCCESS_GET_TAX_RATES    = "Tax rates retrieved successfully"
+	SUCCESS_DELETE_TAX_RATE  = "Tax rate deleted successfully"
+
+	SUCCESS_SET_COST_PRICE    = "Cost price saved successfully"
+	SUCCESS_GET_MARGIN_REPORT = "Margin report retrieved successfully"
+
+	SUCCESS_GET_INVENTORY_VALUATION = "Inventory valuation retrieved successfully"
+
+	SUCCESS_CREATE_PROMOTION = "Promotion created successfully"
+	SUCCESS_GET_PROMOTION    = "Promotion retrieved successfully"
+	SUCCESS_GET_PROMOTIONS   = "Promotions retrieved successfully"
+	SUCCESS_UPDATE_PROMOTION = "Promotion updated successfully"
+	SUCCESS_DELETE_PROMOTION = "Promotion deleted successfully"
+)
@@ -3,4 +3,4 @@ package status
 const (
 	STATUS_SUCCESS = "success"
 	STATUS_FAILED  = "failed"
-)
\ No newline at end of file
+)
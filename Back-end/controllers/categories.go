@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	msg "product-manager/constant/messages"
+	dto "product-manager/dto/categories"
+	"product-manager/usecases"
+	err_util "product-manager/utils/error"
+	http_util "product-manager/utils/http"
+	"product-manager/utils/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CategoryController struct {
+	UseCase   usecases.CategoryUseCase
+	Validator *validation.Validator
+}
+
+func NewCategoryController(useCase usecases.CategoryUseCase, validator *validation.Validator) *CategoryController {
+	return &CategoryController{
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+func (cc *CategoryController) RegisterRoutes(g *echo.Group) {
+	g.GET("/categories", cc.GetAll)
+	g.GET("/categories/tree", cc.GetTree)
+	g.GET("/categories/:id", cc.Get)
+	g.POST("/categories", cc.Create)
+	g.PUT("/categories/:id", cc.Update)
+	g.POST("/categories/:id/move", cc.Move)
+	g.DELETE("/categories/:id", cc.Delete)
+}
+
+func (cc *CategoryController) Create(c echo.Context) error {
+	var req dto.CategoryRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := cc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := cc.UseCase.Create(c.Request().Context(), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, categoryErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.SUCCESS_CREATE_CATEGORY, res)
+}
+
+// Get accepts either a numeric ID or a slug.
+func (cc *CategoryController) Get(c echo.Context) error {
+	res, err := cc.UseCase.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, categoryErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_CATEGORY, res)
+}
+
+func (cc *CategoryController) GetAll(c echo.Context) error {
+	res, err := cc.UseCase.GetAll(c.Request().Context())
+	if err != nil {
+		return http_util.HandleErrorResponse(c, categoryErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_CATEGORIES, res)
+}
+
+func (cc *CategoryController) GetTree(c echo.Context) error {
+	res, err := cc.UseCase.GetTree(c.Request().Context())
+	if err != nil {
+		return http_util.HandleErrorResponse(c, categoryErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_CATEGORY_TREE, res)
+}
+
+func (cc *CategoryController) Update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_CATEGORY_ID)
+	}
+	var req dto.CategoryRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := cc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := cc.UseCase.Update(c.Request().Context(), uint(id), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, categoryErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_UPDATE_CATEGORY, res)
+}
+
+func (cc *CategoryController) Move(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_CATEGORY_ID)
+	}
+	var req dto.CategoryMoveRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := cc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := cc.UseCase.Move(c.Request().Context(), uint(id), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, categoryErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_MOVE_CATEGORY, res)
+}
+
+func (cc *CategoryController) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_CATEGORY_ID)
+	}
+	if err := cc.UseCase.Delete(c.Request().Context(), uint(id)); err != nil {
+		return http_util.HandleErrorResponse(c, categoryErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_DELETE_CATEGORY, nil)
+}
+
+func categoryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, err_util.ErrInvalidCategoryID),
+		errors.Is(err, err_util.ErrCategoryNameRequired),
+		errors.Is(err, err_util.ErrInvalidCategorySlug):
+		return http.StatusBadRequest
+	case errors.Is(err, err_util.ErrCategoryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, err_util.ErrCategoryAlreadyExists),
+		errors.Is(err, err_util.ErrCategoryInUse),
+		errors.Is(err, err_util.ErrCategoryHasChildren),
+		errors.Is(err, err_util.ErrCategoryCycle):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	msg "product-manager/constant/messages"
+	dto "product-manager/dto/currencies"
+	"product-manager/usecases"
+	err_util "product-manager/utils/error"
+	http_util "product-manager/utils/http"
+	"product-manager/utils/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ExchangeRateController struct {
+	UseCase   usecases.ExchangeRateUseCase
+	Validator *validation.Validator
+}
+
+func NewExchangeRateController(useCase usecases.ExchangeRateUseCase, validator *validation.Validator) *ExchangeRateController {
+	return &ExchangeRateController{
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+func (ec *ExchangeRateController) RegisterRoutes(g *echo.Group) {
+	g.GET("/exchange-rates", ec.GetAll)
+	g.PUT("/exchange-rates/:base/:quote", ec.Set)
+	g.DELETE("/exchange-rates/:base/:quote", ec.Delete)
+}
+
+func (ec *ExchangeRateController) GetAll(c echo.Context) error {
+	res, err := ec.UseCase.GetAll(c.Request().Context())
+	if err != nil {
+		return http_util.HandleErrorResponse(c, currencyErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_EXCHANGE_RATES, res)
+}
+
+// Set takes the rate as the price of one base unit in quote units, like
+// {"rate": 0.0000612} for IDR/USD. It is kept to 10 decimal places.
+func (ec *ExchangeRateController) Set(c echo.Context) error {
+	var req dto.ExchangeRateRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := ec.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := ec.UseCase.Set(c.Request().Context(), c.Param("base"), c.Param("quote"), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, currencyErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_SET_EXCHANGE_RATE, res)
+}
+
+func (ec *ExchangeRateController) Delete(c echo.Context) error {
+	if err := ec.UseCase.Delete(c.Request().Context(), c.Param("base"), c.Param("quote")); err != nil {
+		return http_util.HandleErrorResponse(c, currencyErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_DELETE_EXCHANGE_RATE, nil)
+}
+
+func currencyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, err_util.ErrUnsupportedCurrency),
+		errors.Is(err, err_util.ErrInvalidExchangeRate),
+		errors.Is(err, err_util.ErrSameCurrencyRate):
+		return http.StatusBadRequest
+	case errors.Is(err, err_util.ErrExchangeRateNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	msg "product-manager/constant/messages"
+	"product-manager/entities"
+	"product-manager/usecases"
+	err_util "product-manager/utils/error"
+	http_util "product-manager/utils/http"
+	"product-manager/utils/token"
+	"product-manager/utils/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+type InventoryValuationController struct {
+	UseCase   usecases.InventoryValuationUseCase
+	Validator *validation.Validator
+}
+
+func NewInventoryValuationController(useCase usecases.InventoryValuationUseCase, validator *validation.Validator) *InventoryValuationController {
+	return &InventoryValuationController{
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+func (vc *InventoryValuationController) RegisterRoutes(g *echo.Group) {
+	g.GET("/reports/inventory-valuation", vc.GetValuation, token.RequirePermission(token.PermissionFinance))
+}
+
+// GetValuation values stock as it stood at ?as_of=, an RFC 3339 time or a
+// date taken as the close of that day, and now when it is left out. method
+// is fifo, the default, or weighted_average.
+func (vc *InventoryValuationController) GetValuation(c echo.Context) error {
+	at := time.Now()
+	if raw := c.QueryParam("as_of"); raw != "" {
+		asOf, err := parseDateParam(raw, true)
+		if err != nil {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_VALUATION_DATE)
+		}
+		at = *asOf
+	}
+	method := c.QueryParam("method")
+	if method == "" {
+		method = entities.ValuationFIFO
+	}
+
+	res, err := vc.UseCase.GetValuation(c.Request().Context(), at, method)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, valuationErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_INVENTORY_VALUATION, res)
+}
+
+func valuationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, err_util.ErrInvalidValuationMethod):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
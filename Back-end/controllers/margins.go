@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	msg "product-manager/constant/messages"
+	dto "product-manager/dto/reports"
+	"product-manager/usecases"
+	"product-manager/utils/export"
+	http_util "product-manager/utils/http"
+	"product-manager/utils/token"
+	"product-manager/utils/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	marginLevelProduct  = "product"
+	marginLevelCategory = "category"
+)
+
+var (
+	productMarginColumns  = []string{"product_id", "name", "category_id", "category", "price", "net_price", "cost_price", "gross_profit", "margin_percent", "markup_percent"}
+	categoryMarginColumns = []string{"category_id", "category", "product_count", "net_price", "cost_price", "gross_profit", "margin_percent", "markup_percent"}
+)
+
+type MarginController struct {
+	UseCase   usecases.MarginUseCase
+	Validator *validation.Validator
+}
+
+func NewMarginController(useCase usecases.MarginUseCase, validator *validation.Validator) *MarginController {
+	return &MarginController{
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+// RegisterRoutes puts every margin route behind the finance permission.
+func (mc *MarginController) RegisterRoutes(g *echo.Group) {
+	finance := token.RequirePermission(token.PermissionFinance)
+	g.PUT("/products/:id/cost-price", mc.SetCostPrice, finance)
+	g.GET("/reports/margins", mc.GetReport, finance)
+}
+
+func (mc *MarginController) SetCostPrice(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	var req dto.CostPriceRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := mc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := mc.UseCase.SetCostPrice(c.Request().Context(), uint(id), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_SET_COST_PRICE, res)
+}
+
+// GetReport takes the product list filters plus min_margin and max_margin
+// in percent. Without format the report comes back as JSON; with one it is
+// downloaded as a file of product rows, or of category rows when level is
+// category.
+func (mc *MarginController) GetReport(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format != "" && format != export.FormatCSV && format != export.FormatXLSX && format != export.FormatNDJSON {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_EXPORT_FORMAT)
+	}
+	level := c.QueryParam("level")
+	if level == "" {
+		level = marginLevelProduct
+	}
+	if level != marginLevelProduct && level != marginLevelCategory {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_MARGIN_LEVEL)
+	}
+
+	sortBy, filter, err := parseProductQuery(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	var margins dto.MarginFilter
+	for param, target := range map[string]**float64{"min_margin": &margins.MinMargin, "max_margin": &margins.MaxMargin} {
+		raw := c.QueryParam(param)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_MARGIN_FILTER)
+		}
+		*target = &v
+	}
+
+	report, err := mc.UseCase.GetReport(c.Request().Context(), sortBy, filter, &margins)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	if format == "" {
+		return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_MARGIN_REPORT, report)
+	}
+
+	columns, rows := productMarginColumns, make([][]any, len(report.Products))
+	for i, p := range report.Products {
+		rows[i] = []any{p.ProductID, p.Name, p.CategoryID, p.Category, p.Price.Amount, p.NetPrice.Amount, p.CostPrice.Amount, p.GrossProfit.Amount, p.MarginPercent, p.MarkupPercent}
+	}
+	if level == marginLevelCategory {
+		columns, rows = categoryMarginColumns, make([][]any, len(report.Categories))
+		for i, m := range report.Categories {
+			rows[i] = []any{m.CategoryID, m.Category, m.ProductCount, m.NetPrice.Amount, m.CostPrice.Amount, m.GrossProfit.Amount, m.MarginPercent, m.MarkupPercent}
+		}
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, export.ContentType(format))
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="margins-%s-%s.%s"`, level, time.Now().Format("20060102-150405"), format))
+	res.WriteHeader(http.StatusOK)
+
+	writer, err := export.NewWriter(format, res, columns)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	msg "product-manager/constant/messages"
+	dto "product-manager/dto/products"
+	"product-manager/entities"
+	"product-manager/usecases"
+	http_util "product-manager/utils/http"
+	"product-manager/utils/validation"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type ProductAuditController struct {
+	UseCase   usecases.ProductAuditUseCase
+	Validator *validation.Validator
+}
+
+func NewProductAuditController(useCase usecases.ProductAuditUseCase, validator *validation.Validator) *ProductAuditController {
+	return &ProductAuditController{
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+func (ac *ProductAuditController) RegisterRoutes(g *echo.Group) {
+	g.GET("/products/audits", ac.GetAll)
+	g.GET("/products/:id/history", ac.GetHistory)
+}
+
+func (ac *ProductAuditController) GetHistory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+
+	req := parsePagination(c)
+	if err := ac.Validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	res, err := ac.UseCase.GetHistory(c.Request().Context(), uint(id), req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_PRODUCT_HISTORY, res)
+}
+
+func (ac *ProductAuditController) GetAll(c echo.Context) error {
+	req := parsePagination(c)
+	if err := ac.Validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	filter := &dto.ProductAuditFilter{}
+
+	if raw := c.QueryParam("admin_id"); raw != "" {
+		adminID, err := uuid.Parse(raw)
+		if err != nil {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_ADMIN_ID)
+		}
+		filter.AdminID = &adminID
+	}
+
+	if action := c.QueryParam("action"); action != "" {
+		if !entities.AuditActions[action] {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_AUDIT_ACTION)
+		}
+		filter.Action = action
+	}
+
+	from, err := parseDateParam(c.QueryParam("from"), false)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_DATE_RANGE)
+	}
+	to, err := parseDateParam(c.QueryParam("to"), true)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_DATE_RANGE)
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_DATE_RANGE)
+	}
+	filter.From = from
+	filter.To = to
+
+	res, err := ac.UseCase.GetAll(c.Request().Context(), req, filter)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_PRODUCT_AUDITS, res)
+}
+
+// parseDateParam accepts RFC 3339 timestamps or plain YYYY-MM-DD dates.
+// A plain date used as an upper bound covers the whole day.
+func parseDateParam(raw string, endOfDay bool) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, raw, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
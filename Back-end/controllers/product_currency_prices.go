@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	msg "product-manager/constant/messages"
+	dto "product-manager/dto/products"
+	"product-manager/usecases"
+	http_util "product-manager/utils/http"
+	"product-manager/utils/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ProductCurrencyPriceController struct {
+	UseCase   usecases.ProductCurrencyPriceUseCase
+	Validator *validation.Validator
+}
+
+func NewProductCurrencyPriceController(useCase usecases.ProductCurrencyPriceUseCase, validator *validation.Validator) *ProductCurrencyPriceController {
+	return &ProductCurrencyPriceController{
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+func (pc *ProductCurrencyPriceController) RegisterRoutes(g *echo.Group) {
+	g.GET("/products/:id/currency-prices", pc.GetByProductID)
+	g.PUT("/products/:id/currency-prices/:currency", pc.Set)
+	g.DELETE("/products/:id/currency-prices/:currency", pc.Delete)
+}
+
+func (pc *ProductCurrencyPriceController) GetByProductID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	res, err := pc.UseCase.GetByProductID(c.Request().Context(), uint(id))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_CURRENCY_PRICES, res)
+}
+
+// Set takes the amount in the minor unit of the currency, so 1234 for
+// USD 12.34.
+func (pc *ProductCurrencyPriceController) Set(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	var req dto.CurrencyPriceRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := pc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := pc.UseCase.Set(c.Request().Context(), uint(id), c.Param("currency"), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_SET_CURRENCY_PRICE, res)
+}
+
+func (pc *ProductCurrencyPriceController) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	if err := pc.UseCase.Delete(c.Request().Context(), uint(id), c.Param("currency")); err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_DELETE_CURRENCY_PRICE, nil)
+}
@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	msg "product-manager/constant/messages"
+	dto "product-manager/dto/products"
+	"product-manager/usecases"
+	http_util "product-manager/utils/http"
+	"product-manager/utils/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+// imageFormOverhead leaves room in the request body for the multipart
+// framing and form fields around the file itself.
+const imageFormOverhead = 1 << 20
+
+type ProductImageController struct {
+	UseCase   usecases.ProductImageUseCase
+	Validator *validation.Validator
+}
+
+func NewProductImageController(useCase usecases.ProductImageUseCase, validator *validation.Validator) *ProductImageController {
+	return &ProductImageController{
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+func (ic *ProductImageController) RegisterRoutes(g *echo.Group) {
+	g.GET("/products/:id/images", ic.GetByProductID)
+	g.POST("/products/:id/images", ic.Upload)
+	g.PATCH("/products/:id/images/:imageId", ic.Update)
+	g.DELETE("/products/:id/images/:imageId", ic.Delete)
+}
+
+// Upload takes a multipart form with the file in "image" and the optional
+// fields "position" and "is_primary".
+func (ic *ProductImageController) Upload(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, usecases.MaxImageSize+imageFormOverhead)
+
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return http_util.HandleErrorResponse(c, http.StatusRequestEntityTooLarge, msg.IMAGE_TOO_LARGE)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.IMAGE_REQUIRED)
+	}
+	if fileHeader.Size > usecases.MaxImageSize {
+		return http_util.HandleErrorResponse(c, http.StatusRequestEntityTooLarge, msg.IMAGE_TOO_LARGE)
+	}
+
+	var opts dto.ProductImageUploadOptions
+	if raw := c.FormValue("position"); raw != "" {
+		position, err := strconv.Atoi(raw)
+		if err != nil || position < 0 {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+		}
+		opts.Position = &position
+	}
+	opts.IsPrimary = c.FormValue("is_primary") == "true"
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.IMAGE_REQUIRED)
+	}
+	defer file.Close()
+
+	res, err := ic.UseCase.Upload(req.Context(), uint(id), file, opts)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.SUCCESS_UPLOAD_IMAGE, res)
+}
+
+func (ic *ProductImageController) GetByProductID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	res, err := ic.UseCase.GetByProductID(c.Request().Context(), uint(id))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_IMAGES, res)
+}
+
+func (ic *ProductImageController) Update(c echo.Context) error {
+	id, imageID, ok := parseImageParams(c)
+	if !ok {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_IMAGE_ID)
+	}
+	var req dto.ProductImageUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := ic.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := ic.UseCase.Update(c.Request().Context(), id, imageID, &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_UPDATE_IMAGE, res)
+}
+
+func (ic *ProductImageController) Delete(c echo.Context) error {
+	id, imageID, ok := parseImageParams(c)
+	if !ok {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_IMAGE_ID)
+	}
+	if err := ic.UseCase.Delete(c.Request().Context(), id, imageID); err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_DELETE_IMAGE, nil)
+}
+
+func parseImageParams(c echo.Context) (productID, imageID uint, ok bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return 0, 0, false
+	}
+	iid, err := strconv.Atoi(c.Param("imageId"))
+	if err != nil || iid <= 0 {
+		return 0, 0, false
+	}
+	return uint(id), uint(iid), true
+}
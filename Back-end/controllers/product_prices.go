@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	msg "product-manager/constant/messages"
+	dto "product-manager/dto/products"
+	"product-manager/usecases"
+	http_util "product-manager/utils/http"
+	"product-manager/utils/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ProductPriceController struct {
+	UseCase   usecases.ProductPriceUseCase
+	Validator *validation.Validator
+}
+
+func NewProductPriceController(useCase usecases.ProductPriceUseCase, validator *validation.Validator) *ProductPriceController {
+	return &ProductPriceController{
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+func (pc *ProductPriceController) RegisterRoutes(g *echo.Group) {
+	g.GET("/products/:id/prices", pc.GetByProductID)
+	g.POST("/products/:id/prices", pc.Schedule)
+	g.DELETE("/products/:id/prices/:priceId", pc.Cancel)
+	g.GET("/products/:id/price", pc.GetAt)
+}
+
+func (pc *ProductPriceController) Schedule(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	var req dto.ProductPriceRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := pc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := pc.UseCase.Schedule(c.Request().Context(), uint(id), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.SUCCESS_SCHEDULE_PRICE, res)
+}
+
+func (pc *ProductPriceController) GetByProductID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	res, err := pc.UseCase.GetByProductID(c.Request().Context(), uint(id))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_PRICES, res)
+}
+
+// GetAt returns the price in effect at ?at=, an RFC 3339 time or a date
+// taken as midnight UTC, and now when it is left out.
+func (pc *ProductPriceController) GetAt(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	at := time.Now()
+	if raw := c.QueryParam("at"); raw != "" {
+		if at, err = parsePriceDate(raw); err != nil {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRICE_DATE)
+		}
+	}
+	res, err := pc.UseCase.GetAt(c.Request().Context(), uint(id), at)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_PRICE, res)
+}
+
+func (pc *ProductPriceController) Cancel(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	priceID, err := strconv.Atoi(c.Param("priceId"))
+	if err != nil || priceID <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRICE_ID)
+	}
+	if err := pc.UseCase.Cancel(c.Request().Context(), uint(id), uint(priceID)); err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_CANCEL_PRICE, nil)
+}
+
+func parsePriceDate(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	msg "product-manager/constant/messages"
+	dto "product-manager/dto/products"
+	"product-manager/usecases"
+	http_util "product-manager/utils/http"
+	"product-manager/utils/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ProductVariantController struct {
+	UseCase   usecases.ProductVariantUseCase
+	Validator *validation.Validator
+}
+
+func NewProductVariantController(useCase usecases.ProductVariantUseCase, validator *validation.Validator) *ProductVariantController {
+	return &ProductVariantController{
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+func (vc *ProductVariantController) RegisterRoutes(g *echo.Group) {
+	g.GET("/products/:id/variants", vc.GetByProductID)
+	g.POST("/products/:id/variants", vc.Create)
+	g.GET("/products/:id/variants/:variantId", vc.GetByID)
+	g.PUT("/products/:id/variants/:variantId", vc.Update)
+	g.DELETE("/products/:id/variants/:variantId", vc.Delete)
+}
+
+func (vc *ProductVariantController) Create(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	var req dto.ProductVariantRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := vc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := vc.UseCase.Create(c.Request().Context(), uint(id), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.SUCCESS_CREATE_VARIANT, res)
+}
+
+func (vc *ProductVariantController) GetByProductID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	res, err := vc.UseCase.GetByProductID(c.Request().Context(), uint(id))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_VARIANTS, res)
+}
+
+func (vc *ProductVariantController) GetByID(c echo.Context) error {
+	id, variantID, ok := parseVariantParams(c)
+	if !ok {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_VARIANT_ID)
+	}
+	res, err := vc.UseCase.GetByID(c.Request().Context(), id, variantID)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_VARIANT, res)
+}
+
+func (vc *ProductVariantController) Update(c echo.Context) error {
+	id, variantID, ok := parseVariantParams(c)
+	if !ok {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_VARIANT_ID)
+	}
+	var req dto.ProductVariantRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := vc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := vc.UseCase.Update(c.Request().Context(), id, variantID, &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_UPDATE_VARIANT, res)
+}
+
+func (vc *ProductVariantController) Delete(c echo.Context) error {
+	id, variantID, ok := parseVariantParams(c)
+	if !ok {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_VARIANT_ID)
+	}
+	if err := vc.UseCase.Delete(c.Request().Context(), id, variantID); err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_DELETE_VARIANT, nil)
+}
+
+func parseVariantParams(c echo.Context) (productID, variantID uint, ok bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return 0, 0, false
+	}
+	vid, err := strconv.Atoi(c.Param("variantId"))
+	if err != nil || vid <= 0 {
+		return 0, 0, false
+	}
+	return uint(id), uint(vid), true
+}
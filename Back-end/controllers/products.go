@@ -1,13 +1,23 @@
 package controllers
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	msg "product-manager/constant/messages"
 	dto_base "product-manager/dto/base"
 	dto "product-manager/dto/products"
 	"product-manager/usecases"
+	err_util "product-manager/utils/error"
+	"product-manager/utils/export"
 	http_util "product-manager/utils/http"
 	"product-manager/utils/validation"
 
@@ -30,8 +40,16 @@ func (pc *ProductController) RegisterRoutes(g *echo.Group) {
 	g.GET("/products", pc.GetAll)
 	g.GET("/products/:id", pc.GetByID)
 	g.POST("/products", pc.Create)
+	g.POST("/products/bulk", pc.Bulk)
+	g.POST("/products/import", pc.Import)
+	g.GET("/products/export", pc.Export)
 	g.PUT("/products/:id", pc.Update)
+	g.PATCH("/products/:id", pc.Patch)
 	g.DELETE("/products/:id", pc.Delete)
+
+	g.GET("/products/trash", pc.GetTrashed)
+	g.POST("/products/:id/restore", pc.Restore)
+	g.DELETE("/products/:id/purge", pc.Purge)
 }
 
 func (pc *ProductController) Create(c echo.Context) error {
@@ -44,20 +62,80 @@ func (pc *ProductController) Create(c echo.Context) error {
 	}
 	res, err := pc.UseCase.Create(c.Request().Context(), &req)
 	if err != nil {
-		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
 	}
 	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.SUCCESS_CREATE_PRODUCT, res)
 }
 
+func (pc *ProductController) Bulk(c echo.Context) error {
+	var req dto.BulkProductRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := pc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := pc.UseCase.Bulk(c.Request().Context(), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	if !res.Committed {
+		return http_util.HandleErrorResponseWithData(c, http.StatusUnprocessableEntity, msg.BULK_OPERATION_ROLLED_BACK, res)
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_BULK_PRODUCTS, res)
+}
+
+// Import streams the "file" part of a multipart upload straight into the CSV
+// reader instead of buffering the whole file. Options come from the query
+// string because the file part is consumed as it arrives.
+func (pc *ProductController) Import(c echo.Context) error {
+	opts := dto.ProductImportOptions{
+		DryRun: c.QueryParam("dry_run") == "true",
+		Upsert: c.QueryParam("upsert") == "true",
+	}
+
+	reader, err := c.Request().MultipartReader()
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.IMPORT_FILE_REQUIRED)
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.IMPORT_FILE_REQUIRED)
+		}
+		if err != nil {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		res, err := pc.UseCase.Import(c.Request().Context(), part, opts)
+		part.Close()
+		if err != nil {
+			return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+		}
+
+		message := msg.SUCCESS_IMPORT_PRODUCTS
+		if opts.DryRun {
+			message = msg.SUCCESS_VALIDATE_IMPORT
+		}
+		return http_util.HandleSuccessResponse(c, http.StatusOK, message, res)
+	}
+}
+
 func (pc *ProductController) GetByID(c echo.Context) error {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
 	}
-	res, err := pc.UseCase.GetByID(c.Request().Context(), uint(id))
+	res, err := pc.UseCase.GetByID(c.Request().Context(), uint(id), c.QueryParam("currency"))
 	if err != nil {
-		return http_util.HandleErrorResponse(c, http.StatusNotFound, err.Error())
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
 	}
+	setETag(c, res.Version)
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_PRODUCT, res)
 }
 
@@ -71,12 +149,109 @@ func (pc *ProductController) GetAll(c echo.Context) error {
 		limit = 10
 	}
 
-	sortBy := c.QueryParam("sort_by")
+	sortBy, filter, err := parseProductQuery(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+
+	req := &dto_base.PaginationRequest{Page: page, Limit: limit, SortBy: sortBy, Query: c.QueryParams()}
+	if c.QueryParams().Has("cursor") {
+		cursor := c.QueryParam("cursor")
+		req.Cursor = &cursor
+	}
+
+	if err := pc.Validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	res, err := pc.UseCase.GetAll(c.Request().Context(), req, filter)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_PRODUCTS_ALL, res)
+}
+
+const exportFlushEvery = 500
+
+var productExportColumns = []string{"id", "name", "category", "category_id", "price", "stock", "version", "created_at", "updated_at"}
+
+// Export streams every product matching the list filters. Once the first
+// byte is written the status can no longer change, so failures past that
+// point only cut the download short.
+func (pc *ProductController) Export(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = export.FormatCSV
+	}
+	if format != export.FormatCSV && format != export.FormatXLSX && format != export.FormatNDJSON {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_EXPORT_FORMAT)
+	}
+
+	sortBy, filter, err := parseProductQuery(c)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+
+	// The download starts with the first row, or once the stream ends for an
+	// empty result, so query errors can still be answered with a status.
+	res := c.Response()
+	var writer export.Writer
+	start := func() error {
+		res.Header().Set(echo.HeaderContentType, export.ContentType(format))
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="products-%s.%s"`, time.Now().Format("20060102-150405"), format))
+		res.WriteHeader(http.StatusOK)
+
+		var err error
+		writer, err = export.NewWriter(format, res, productExportColumns)
+		return err
+	}
+
+	rows := 0
+	err = pc.UseCase.Export(c.Request().Context(), sortBy, filter, func(p *dto.ProductResponse) error {
+		if writer == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		err := writer.Write([]any{p.ID, p.Name, p.Category, p.CategoryID, p.Price.Amount, p.Stock, p.Version, p.CreatedAt, p.UpdatedAt})
+		if err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushEvery == 0 {
+			res.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if !res.Committed {
+			return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+		}
+		c.Logger().Errorf("product export aborted after %d rows: %v", rows, err)
+		return nil
+	}
+
+	if writer == nil {
+		if err := start(); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// parseProductQuery reads the sort and filter parameters shared by the list
+// and export endpoints. sort takes a comma-separated list of fields, each
+// prefixed with "-" for descending order; sort_by is its older spelling.
+func parseProductQuery(c echo.Context) (string, *dto.ProductSearchFilter, error) {
+	sortBy := c.QueryParam("sort")
+	if sortBy == "" {
+		sortBy = c.QueryParam("sort_by")
+	}
 	name := c.QueryParam("name")
-	category := c.QueryParam("category")
 	minPriceStr := c.QueryParam("min_price")
 	maxPriceStr := c.QueryParam("max_price")
 	inStockStr := c.QueryParam("in_stock")
+	variantInStockStr := c.QueryParam("variant_in_stock")
 
 	var minPrice, maxPrice *uint
 	if v, err := strconv.ParseUint(minPriceStr, 10, 64); err == nil {
@@ -88,8 +263,19 @@ func (pc *ProductController) GetAll(c echo.Context) error {
 		maxPrice = &u
 	}
 
-	if sortBy == "" {
-		sortBy = "-created_at"
+	// The default order is left to the repository, which knows whether q
+	// holds anything to search for
+	query := c.QueryParam("q")
+
+	// category takes category IDs or slugs, repeated or comma-separated
+	var categoryIDs []uint
+	var categorySlugs []string
+	for _, category := range splitListParam(c, "category") {
+		if v, err := strconv.ParseUint(category, 10, 64); err == nil {
+			categoryIDs = append(categoryIDs, uint(v))
+		} else {
+			categorySlugs = append(categorySlugs, category)
+		}
 	}
 
 	var inStock *bool
@@ -98,24 +284,89 @@ func (pc *ProductController) GetAll(c echo.Context) error {
 		inStock = &val
 	}
 
-	req := &dto_base.PaginationRequest{Page: page, Limit: limit, SortBy: sortBy}
-	filter := &dto.ProductSearchFilter{
-		Name:     name,
-		Category: category,
-		MinPrice: minPrice,
-		MaxPrice: maxPrice,
-		InStock:  inStock,
+	var variantInStock *bool
+	if variantInStockStr != "" {
+		val := variantInStockStr == "true"
+		variantInStock = &val
 	}
 
-	if err := pc.Validator.Validate(req); err != nil {
-		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	conditions, err := parseFilterConditions(c)
+	if err != nil {
+		return "", nil, err
 	}
 
-	res, err := pc.UseCase.GetAll(c.Request().Context(), req, filter)
-	if err != nil {
-		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, err.Error())
+	return sortBy, &dto.ProductSearchFilter{
+		Query:              query,
+		Name:               name,
+		CategoryIDs:        categoryIDs,
+		CategorySlugs:      categorySlugs,
+		IncludeDescendants: c.QueryParam("include_descendants") == "true",
+		SKU:                c.QueryParam("sku"),
+		VariantInStock:     variantInStock,
+		MinPrice:           minPrice,
+		MaxPrice:           maxPrice,
+		InStock:            inStock,
+		Conditions:         conditions,
+		Facets:             splitListParam(c, "facets"),
+		Currency:           c.QueryParam("currency"),
+	}, nil
+}
+
+var filterParamPattern = regexp.MustCompile(`^filter\[([^\[\]]+)\](?:\[([^\[\]]+)\])?$`)
+
+// parseFilterConditions reads filter[field][op]=value parameters, with eq
+// as the default operator when [op] is left out. Which fields and operators
+// are allowed is up to the repository; only the syntax is checked here.
+func parseFilterConditions(c echo.Context) ([]dto.FilterCondition, error) {
+	params := c.QueryParams()
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		if strings.HasPrefix(key, "filter") {
+			keys = append(keys, key)
+		}
 	}
-	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_PRODUCTS_ALL, res)
+	// A stable order keeps the generated SQL the same between requests
+	slices.Sort(keys)
+
+	var conditions []dto.FilterCondition
+	for _, key := range keys {
+		match := filterParamPattern.FindStringSubmatch(key)
+		if match == nil {
+			return nil, fmt.Errorf("%w: %s", err_util.ErrInvalidFilterField, key)
+		}
+
+		op := match[2]
+		if op == "" {
+			op = dto.FilterEq
+		}
+		for _, raw := range params[key] {
+			values := []string{raw}
+			if op == dto.FilterIn || op == dto.FilterBetween {
+				values = nil
+				for _, v := range strings.Split(raw, ",") {
+					if v = strings.TrimSpace(v); v != "" {
+						values = append(values, v)
+					}
+				}
+			}
+			conditions = append(conditions, dto.FilterCondition{Field: match[1], Op: op, Values: values})
+		}
+	}
+	return conditions, nil
+}
+
+// splitListParam collects the values of a query parameter that may be
+// repeated, comma-separated, or both.
+func splitListParam(c echo.Context, name string) []string {
+	var values []string
+	for _, raw := range c.QueryParams()[name] {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
 }
 
 func (pc *ProductController) Update(c echo.Context) error {
@@ -123,6 +374,10 @@ func (pc *ProductController) Update(c echo.Context) error {
 	if err != nil {
 		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
 	}
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return http_util.HandleErrorResponse(c, http.StatusPreconditionFailed, msg.PRODUCT_VERSION_CONFLICT)
+	}
 	var req dto.ProductRequest
 	if err := c.Bind(&req); err != nil {
 		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
@@ -130,10 +385,40 @@ func (pc *ProductController) Update(c echo.Context) error {
 	if err := pc.Validator.Validate(&req); err != nil {
 		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
 	}
-	res, err := pc.UseCase.Update(c.Request().Context(), uint(id), &req)
+	res, err := pc.UseCase.Update(c.Request().Context(), uint(id), &req, version)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	setETag(c, res.Version)
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_UPDATE_PRODUCT, res)
+}
+
+// Patch accepts a JSON Merge Patch document, sent either as
+// application/merge-patch+json or plain application/json.
+func (pc *ProductController) Patch(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return http_util.HandleErrorResponse(c, http.StatusPreconditionFailed, msg.PRODUCT_VERSION_CONFLICT)
+	}
+	var req dto.ProductPatchRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
+		if errors.Is(err, io.EOF) {
+			return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+		}
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	if err := pc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := pc.UseCase.Patch(c.Request().Context(), uint(id), &req, version)
 	if err != nil {
-		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
 	}
+	setETag(c, res.Version)
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_UPDATE_PRODUCT, res)
 }
 
@@ -142,8 +427,152 @@ func (pc *ProductController) Delete(c echo.Context) error {
 	if err != nil {
 		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
 	}
-	if err := pc.UseCase.Delete(c.Request().Context(), uint(id)); err != nil {
-		return http_util.HandleErrorResponse(c, http.StatusInternalServerError, err.Error())
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return http_util.HandleErrorResponse(c, http.StatusPreconditionFailed, msg.PRODUCT_VERSION_CONFLICT)
+	}
+	if err := pc.UseCase.Delete(c.Request().Context(), uint(id), version); err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
 	}
 	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_DELETE_PRODUCT, nil)
 }
+
+func (pc *ProductController) GetTrashed(c echo.Context) error {
+	req := parsePagination(c)
+	if err := pc.Validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+
+	res, err := pc.UseCase.GetTrashed(c.Request().Context(), req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_TRASHED_PRODUCTS, res)
+}
+
+func (pc *ProductController) Restore(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	res, err := pc.UseCase.Restore(c.Request().Context(), uint(id))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_RESTORE_PRODUCT, res)
+}
+
+func (pc *ProductController) Purge(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	if err := pc.UseCase.Purge(c.Request().Context(), uint(id)); err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_PURGE_PRODUCT, nil)
+}
+
+func parsePagination(c echo.Context) *dto_base.PaginationRequest {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	return &dto_base.PaginationRequest{Page: page, Limit: limit}
+}
+
+func setETag(c echo.Context, version uint) {
+	c.Response().Header().Set("ETag", strconv.Quote(strconv.FormatUint(uint64(version), 10)))
+}
+
+// parseIfMatch returns the version named by the If-Match header, or nil when
+// the header is absent or "*". ok is false when the header holds no usable
+// strong ETag, which can never match the current version.
+func parseIfMatch(c echo.Context) (version *uint, ok bool) {
+	raw := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if raw == "" || raw == "*" {
+		return nil, true
+	}
+
+	tag, err := strconv.Unquote(raw)
+	if err != nil {
+		return nil, false
+	}
+	v, err := strconv.ParseUint(tag, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	u := uint(v)
+	return &u, true
+}
+
+func productErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, err_util.ErrInvalidProductID),
+		errors.Is(err, err_util.ErrInvalidCursor),
+		errors.Is(err, err_util.ErrInvalidFacet),
+		errors.Is(err, err_util.ErrInvalidSortField),
+		errors.Is(err, err_util.ErrInvalidFilterField),
+		errors.Is(err, err_util.ErrInvalidFilterOp),
+		errors.Is(err, err_util.ErrInvalidFilterValue),
+		errors.Is(err, err_util.ErrProductNameRequired),
+		errors.Is(err, err_util.ErrProductCategoryRequired),
+		errors.Is(err, err_util.ErrProductPriceRequired),
+		errors.Is(err, err_util.ErrInvalidStockMovementType),
+		errors.Is(err, err_util.ErrInvalidStockQuantity),
+		errors.Is(err, err_util.ErrImportFileRequired),
+		errors.Is(err, err_util.ErrImportMissingColumn),
+		errors.Is(err, err_util.ErrImportInvalidCSV),
+		errors.Is(err, err_util.ErrInvalidCategoryID),
+		errors.Is(err, err_util.ErrCategoryNotFound),
+		errors.Is(err, err_util.ErrInvalidTaxClassID),
+		errors.Is(err, err_util.ErrTaxClassNotFound),
+		errors.Is(err, err_util.ErrInvalidVariantID),
+		errors.Is(err, err_util.ErrVariantSKURequired),
+		errors.Is(err, err_util.ErrVariantOptionsRequired),
+		errors.Is(err, err_util.ErrInvalidImageID),
+		errors.Is(err, err_util.ErrImageRequired),
+		errors.Is(err, err_util.ErrInvalidImage),
+		errors.Is(err, err_util.ErrInvalidPriceID),
+		errors.Is(err, err_util.ErrInvalidPriceDate),
+		errors.Is(err, err_util.ErrPriceStartNotInFuture),
+		errors.Is(err, err_util.ErrInvalidPricePeriod),
+		errors.Is(err, err_util.ErrUnsupportedCurrency),
+		errors.Is(err, err_util.ErrBaseCurrencyPrice),
+		errors.Is(err, err_util.ErrNotBaseCurrency),
+		errors.Is(err, err_util.ErrInvalidAmount),
+		errors.Is(err, err_util.ErrUnitCostNotAllowed),
+		errors.Is(err, err_util.ErrVariantRequired):
+		return http.StatusBadRequest
+	case errors.Is(err, err_util.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, err_util.ErrImageTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, err_util.ErrUnsupportedImageType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, err_util.ErrProductNotFound),
+		errors.Is(err, err_util.ErrProductNotInTrash),
+		errors.Is(err, err_util.ErrVariantNotFound),
+		errors.Is(err, err_util.ErrImageNotFound),
+		errors.Is(err, err_util.ErrPriceNotFound),
+		errors.Is(err, err_util.ErrCurrencyPriceNotFound),
+		errors.Is(err, err_util.ErrPageNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, err_util.ErrProductVersionConflict):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, err_util.ErrProductAlreadyExists),
+		errors.Is(err, err_util.ErrInsufficientStock),
+		errors.Is(err, err_util.ErrVariantSKUAlreadyExists),
+		errors.Is(err, err_util.ErrVariantOptionsAlreadyExist),
+		errors.Is(err, err_util.ErrPriceAlreadyEffective):
+		return http.StatusConflict
+	case errors.Is(err, err_util.ErrExchangeRateNotFound):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	msg "product-manager/constant/messages"
+	dto "product-manager/dto/promotions"
+	"product-manager/usecases"
+	err_util "product-manager/utils/error"
+	http_util "product-manager/utils/http"
+	"product-manager/utils/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PromotionController struct {
+	UseCase   usecases.PromotionUseCase
+	Validator *validation.Validator
+}
+
+func NewPromotionController(useCase usecases.PromotionUseCase, validator *validation.Validator) *PromotionController {
+	return &PromotionController{
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+func (pc *PromotionController) RegisterRoutes(g *echo.Group) {
+	g.GET("/promotions", pc.GetAll)
+	g.POST("/promotions", pc.Create)
+	g.GET("/promotions/:id", pc.GetByID)
+	g.PUT("/promotions/:id", pc.Update)
+	g.DELETE("/promotions/:id", pc.Delete)
+}
+
+func (pc *PromotionController) Create(c echo.Context) error {
+	var req dto.PromotionRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := pc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := pc.UseCase.Create(c.Request().Context(), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, promotionErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.SUCCESS_CREATE_PROMOTION, res)
+}
+
+// GetAll lists promotions, optionally narrowed with status to those
+// scheduled, active or ended.
+func (pc *PromotionController) GetAll(c echo.Context) error {
+	res, err := pc.UseCase.GetAll(c.Request().Context(), c.QueryParam("status"))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, promotionErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_PROMOTIONS, res)
+}
+
+func (pc *PromotionController) GetByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PROMOTION_ID)
+	}
+	res, err := pc.UseCase.GetByID(c.Request().Context(), uint(id))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, promotionErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_PROMOTION, res)
+}
+
+func (pc *PromotionController) Update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PROMOTION_ID)
+	}
+	var req dto.PromotionRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := pc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := pc.UseCase.Update(c.Request().Context(), uint(id), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, promotionErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_UPDATE_PROMOTION, res)
+}
+
+func (pc *PromotionController) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PROMOTION_ID)
+	}
+	if err := pc.UseCase.Delete(c.Request().Context(), uint(id)); err != nil {
+		return http_util.HandleErrorResponse(c, promotionErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_DELETE_PROMOTION, nil)
+}
+
+// promotionErrorStatus treats missing targets as a bad request, since they
+// come from the request body rather than the path.
+func promotionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, err_util.ErrInvalidPromotionID),
+		errors.Is(err, err_util.ErrInvalidPromotionValue),
+		errors.Is(err, err_util.ErrInvalidPromotionPeriod),
+		errors.Is(err, err_util.ErrPromotionTargetsRequired),
+		errors.Is(err, err_util.ErrPromotionTargetsNotAllowed),
+		errors.Is(err, err_util.ErrInvalidPromotionStatus),
+		errors.Is(err, err_util.ErrUnsupportedCurrency),
+		errors.Is(err, err_util.ErrNotBaseCurrency),
+		errors.Is(err, err_util.ErrProductNotFound),
+		errors.Is(err, err_util.ErrCategoryNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err, err_util.ErrPromotionNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	msg "product-manager/constant/messages"
+	dto "product-manager/dto/stocks"
+	"product-manager/usecases"
+	http_util "product-manager/utils/http"
+	"product-manager/utils/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+type StockMovementController struct {
+	UseCase   usecases.StockMovementUseCase
+	Validator *validation.Validator
+}
+
+func NewStockMovementController(useCase usecases.StockMovementUseCase, validator *validation.Validator) *StockMovementController {
+	return &StockMovementController{
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+func (sc *StockMovementController) RegisterRoutes(g *echo.Group) {
+	g.POST("/products/:id/stock-movements", sc.Create)
+	g.GET("/products/:id/stock-movements", sc.GetByProductID)
+	g.GET("/products/:id/stock-movements/reconcile", sc.Reconcile)
+	g.GET("/stock-movements/discrepancies", sc.GetDiscrepancies)
+	g.GET("/products/low-stock", sc.GetLowStock)
+}
+
+func (sc *StockMovementController) Create(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	var req dto.StockMovementRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := sc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := sc.UseCase.Record(c.Request().Context(), uint(id), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.SUCCESS_CREATE_STOCK_MOVEMENT, res)
+}
+
+func (sc *StockMovementController) GetByProductID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	req := parsePagination(c)
+	if err := sc.Validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	res, err := sc.UseCase.GetByProductID(c.Request().Context(), uint(id), req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_STOCK_MOVEMENTS, res)
+}
+
+func (sc *StockMovementController) Reconcile(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_PRODUCT_ID)
+	}
+	res, err := sc.UseCase.Reconcile(c.Request().Context(), uint(id))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_RECONCILE_STOCK, res)
+}
+
+func (sc *StockMovementController) GetDiscrepancies(c echo.Context) error {
+	res, err := sc.UseCase.GetDiscrepancies(c.Request().Context())
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_RECONCILE_STOCK, res)
+}
+
+func (sc *StockMovementController) GetLowStock(c echo.Context) error {
+	req := parsePagination(c)
+	if err := sc.Validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	res, err := sc.UseCase.GetLowStock(c.Request().Context(), req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, productErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_LOW_STOCK, res)
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	msg "product-manager/constant/messages"
+	dto "product-manager/dto/taxes"
+	"product-manager/usecases"
+	err_util "product-manager/utils/error"
+	http_util "product-manager/utils/http"
+	"product-manager/utils/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TaxClassController struct {
+	UseCase   usecases.TaxClassUseCase
+	Validator *validation.Validator
+}
+
+func NewTaxClassController(useCase usecases.TaxClassUseCase, validator *validation.Validator) *TaxClassController {
+	return &TaxClassController{
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+func (tc *TaxClassController) RegisterRoutes(g *echo.Group) {
+	g.GET("/tax-classes", tc.GetAll)
+	g.POST("/tax-classes", tc.Create)
+	g.GET("/tax-classes/:id", tc.GetByID)
+	g.PUT("/tax-classes/:id", tc.Update)
+	g.DELETE("/tax-classes/:id", tc.Delete)
+	g.GET("/tax-classes/:id/rates", tc.GetRates)
+	g.POST("/tax-classes/:id/rates", tc.CreateRate)
+	g.DELETE("/tax-classes/:id/rates/:rateId", tc.DeleteRate)
+}
+
+func (tc *TaxClassController) Create(c echo.Context) error {
+	var req dto.TaxClassRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := tc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := tc.UseCase.Create(c.Request().Context(), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, taxErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.SUCCESS_CREATE_TAX_CLASS, res)
+}
+
+func (tc *TaxClassController) GetAll(c echo.Context) error {
+	res, err := tc.UseCase.GetAll(c.Request().Context())
+	if err != nil {
+		return http_util.HandleErrorResponse(c, taxErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_TAX_CLASSES, res)
+}
+
+func (tc *TaxClassController) GetByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TAX_CLASS_ID)
+	}
+	res, err := tc.UseCase.GetByID(c.Request().Context(), uint(id))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, taxErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_TAX_CLASS, res)
+}
+
+func (tc *TaxClassController) Update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TAX_CLASS_ID)
+	}
+	var req dto.TaxClassRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := tc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := tc.UseCase.Update(c.Request().Context(), uint(id), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, taxErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_UPDATE_TAX_CLASS, res)
+}
+
+func (tc *TaxClassController) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TAX_CLASS_ID)
+	}
+	if err := tc.UseCase.Delete(c.Request().Context(), uint(id)); err != nil {
+		return http_util.HandleErrorResponse(c, taxErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_DELETE_TAX_CLASS, nil)
+}
+
+func (tc *TaxClassController) CreateRate(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TAX_CLASS_ID)
+	}
+	var req dto.TaxRateRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := tc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := tc.UseCase.CreateRate(c.Request().Context(), uint(id), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, taxErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.SUCCESS_CREATE_TAX_RATE, res)
+}
+
+func (tc *TaxClassController) GetRates(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TAX_CLASS_ID)
+	}
+	res, err := tc.UseCase.GetRates(c.Request().Context(), uint(id))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, taxErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_TAX_RATES, res)
+}
+
+func (tc *TaxClassController) DeleteRate(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TAX_CLASS_ID)
+	}
+	rateID, err := strconv.Atoi(c.Param("rateId"))
+	if err != nil || rateID <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_TAX_RATE_ID)
+	}
+	if err := tc.UseCase.DeleteRate(c.Request().Context(), uint(id), uint(rateID)); err != nil {
+		return http_util.HandleErrorResponse(c, taxErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_DELETE_TAX_RATE, nil)
+}
+
+func taxErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, err_util.ErrInvalidTaxClassID),
+		errors.Is(err, err_util.ErrInvalidTaxRateID):
+		return http.StatusBadRequest
+	case errors.Is(err, err_util.ErrTaxClassNotFound),
+		errors.Is(err, err_util.ErrTaxRateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, err_util.ErrTaxClassAlreadyExists),
+		errors.Is(err, err_util.ErrTaxClassInUse),
+		errors.Is(err, err_util.ErrTaxRateAlreadyExists),
+		errors.Is(err, err_util.ErrTaxRateAlreadyEffective):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	msg "product-manager/constant/messages"
+	dto "product-manager/dto/webhooks"
+	"product-manager/entities"
+	"product-manager/usecases"
+	err_util "product-manager/utils/error"
+	http_util "product-manager/utils/http"
+	"product-manager/utils/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookController struct {
+	UseCase   usecases.WebhookUseCase
+	Validator *validation.Validator
+}
+
+func NewWebhookController(useCase usecases.WebhookUseCase, validator *validation.Validator) *WebhookController {
+	return &WebhookController{
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+func (wc *WebhookController) RegisterRoutes(g *echo.Group) {
+	g.GET("/webhooks", wc.GetAll)
+	g.POST("/webhooks", wc.Create)
+	g.GET("/webhooks/:id", wc.GetByID)
+	g.PUT("/webhooks/:id", wc.Update)
+	g.DELETE("/webhooks/:id", wc.Delete)
+	g.GET("/webhooks/:id/deliveries", wc.GetDeliveries)
+	g.GET("/webhooks/:id/deliveries/:deliveryId", wc.GetDelivery)
+	g.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", wc.Redeliver)
+}
+
+func (wc *WebhookController) Create(c echo.Context) error {
+	var req dto.WebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := wc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := wc.UseCase.Create(c.Request().Context(), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, webhookErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusCreated, msg.SUCCESS_CREATE_WEBHOOK, res)
+}
+
+func (wc *WebhookController) GetAll(c echo.Context) error {
+	res, err := wc.UseCase.GetAll(c.Request().Context())
+	if err != nil {
+		return http_util.HandleErrorResponse(c, webhookErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_WEBHOOKS, res)
+}
+
+func (wc *WebhookController) GetByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_WEBHOOK_ID)
+	}
+	res, err := wc.UseCase.GetByID(c.Request().Context(), uint(id))
+	if err != nil {
+		return http_util.HandleErrorResponse(c, webhookErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_WEBHOOK, res)
+}
+
+func (wc *WebhookController) Update(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_WEBHOOK_ID)
+	}
+	var req dto.WebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	if err := wc.Validator.Validate(&req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := wc.UseCase.Update(c.Request().Context(), uint(id), &req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, webhookErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_UPDATE_WEBHOOK, res)
+}
+
+func (wc *WebhookController) Delete(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_WEBHOOK_ID)
+	}
+	if err := wc.UseCase.Delete(c.Request().Context(), uint(id)); err != nil {
+		return http_util.HandleErrorResponse(c, webhookErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_DELETE_WEBHOOK, nil)
+}
+
+// GetDeliveries lists the delivery log, optionally narrowed with status.
+func (wc *WebhookController) GetDeliveries(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_WEBHOOK_ID)
+	}
+	status := c.QueryParam("status")
+	if status != "" && status != entities.WebhookDeliveryPending && status != entities.WebhookDeliverySucceeded && status != entities.WebhookDeliveryFailed {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	req := parsePagination(c)
+	if err := wc.Validator.Validate(req); err != nil {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_REQUEST_DATA)
+	}
+	res, err := wc.UseCase.GetDeliveries(c.Request().Context(), uint(id), status, req)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, webhookErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_WEBHOOK_LOG, res)
+}
+
+func (wc *WebhookController) GetDelivery(c echo.Context) error {
+	id, deliveryID, ok := parseDeliveryParams(c)
+	if !ok {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_WEBHOOK_DELIVERY_ID)
+	}
+	res, err := wc.UseCase.GetDelivery(c.Request().Context(), id, deliveryID)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, webhookErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusOK, msg.SUCCESS_GET_WEBHOOK_DELIVERY, res)
+}
+
+func (wc *WebhookController) Redeliver(c echo.Context) error {
+	id, deliveryID, ok := parseDeliveryParams(c)
+	if !ok {
+		return http_util.HandleErrorResponse(c, http.StatusBadRequest, msg.INVALID_WEBHOOK_DELIVERY_ID)
+	}
+	res, err := wc.UseCase.Redeliver(c.Request().Context(), id, deliveryID)
+	if err != nil {
+		return http_util.HandleErrorResponse(c, webhookErrorStatus(err), err.Error())
+	}
+	return http_util.HandleSuccessResponse(c, http.StatusAccepted, msg.SUCCESS_REDELIVER_WEBHOOK, res)
+}
+
+func parseDeliveryParams(c echo.Context) (uint, uint, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		return 0, 0, false
+	}
+	deliveryID, err := strconv.Atoi(c.Param("deliveryId"))
+	if err != nil || deliveryID <= 0 {
+		return 0, 0, false
+	}
+	return uint(id), uint(deliveryID), true
+}
+
+func webhookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, err_util.ErrInvalidWebhookID),
+		errors.Is(err, err_util.ErrInvalidWebhookURL),
+		errors.Is(err, err_util.ErrInvalidWebhookEvent),
+		errors.Is(err, err_util.ErrInvalidWebhookDeliveryID):
+		return http.StatusBadRequest
+	case errors.Is(err, err_util.ErrWebhookNotFound),
+		errors.Is(err, err_util.ErrWebhookDeliveryNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,79 @@
+package databases
+
+// sqlMigrations run in order after AutoMigrate on every start, so each
+// statement must be idempotent.
+var sqlMigrations = []string{
+	// Turn the free-text categories into rows; the slug expression mirrors
+	// entities.Slugify
+	`INSERT INTO categories (name, slug, created_at, updated_at)
+	SELECT DISTINCT ON (slug) name, slug, NOW(), NOW()
+	FROM (
+		SELECT TRIM(category) AS name,
+			TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(TRIM(category)), '[^a-z0-9]+', '-', 'g')) AS slug
+		FROM products
+		WHERE category_id IS NULL
+	) c
+	WHERE slug <> ''
+	ORDER BY slug, name
+	ON CONFLICT (slug) DO NOTHING`,
+	// Place categories that predate the tree at the root, after any
+	// existing roots, in name order
+	`UPDATE categories c
+	SET path = '/' || c.id || '/', position = o.position
+	FROM (
+		SELECT id, (SELECT COUNT(*) FROM categories WHERE parent_id IS NULL AND path <> '')
+			+ ROW_NUMBER() OVER (ORDER BY name, id) - 1 AS position
+		FROM categories
+		WHERE path = ''
+	) o
+	WHERE c.id = o.id`,
+	// Subtree lookups match path prefixes
+	`CREATE INDEX IF NOT EXISTS idx_categories_path ON categories (path varchar_pattern_ops)`,
+	// Point products at their category and settle on its spelling
+	`UPDATE products p
+	SET category_id = c.id, category = c.name
+	FROM categories c
+	WHERE p.category_id IS NULL
+	AND c.slug = TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(TRIM(p.category)), '[^a-z0-9]+', '-', 'g'))`,
+	// Open the stock ledger for products that predate it
+	`INSERT INTO stock_movements (product_id, type, quantity, stock_after, reason, created_at)
+	SELECT p.id, 'adjustment', p.stock, p.stock, 'opening balance', NOW()
+	FROM products p
+	WHERE p.stock > 0
+	AND NOT EXISTS (SELECT 1 FROM stock_movements m WHERE m.product_id = p.id)`,
+	// Open the price history for products that predate it
+	`INSERT INTO product_prices (product_id, price, effective_from, reason, applied_at, created_at)
+	SELECT p.id, p.price, p.created_at, 'opening price', NOW(), NOW()
+	FROM products p
+	WHERE NOT EXISTS (SELECT 1 FROM product_prices pp WHERE pp.product_id = p.id)`,
+	// A product has at most one primary image
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_product_images_primary ON product_images (product_id) WHERE is_primary`,
+	// Full-text search over name and category; the simple configuration
+	// leaves words unstemmed since the catalog mixes languages
+	`ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector
+	GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', COALESCE(name, '')), 'A') ||
+		setweight(to_tsvector('simple', COALESCE(category, '')), 'B')
+	) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN (search_vector)`,
+	// Webhook fan-out looks subscriptions up by the events they list
+	`CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_events ON webhook_subscriptions USING GIN (events)`,
+	// The outbox dispatcher walks pending events in id order
+	`CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (id) WHERE dispatched_at IS NULL AND failed_at IS NULL`,
+	// A product has at most one price per currency, which saving a price
+	// upserts on
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_product_currency_prices_currency ON product_currency_prices (product_id, price_currency)`,
+	// Fixed promotions moved their amount off value, which now only holds
+	// percentages
+	`UPDATE promotions SET amount = value, value = 0 WHERE type = 'fixed' AND amount IS NULL`,
+	// Start a fresh catalog with standard PPN at 11%, in force since
+	// 1 April 2022
+	`INSERT INTO tax_classes (name, description, created_at, updated_at)
+	SELECT 'PPN', 'Standard value added tax', NOW(), NOW()
+	WHERE NOT EXISTS (SELECT 1 FROM tax_classes)`,
+	`INSERT INTO tax_rates (tax_class_id, rate, effective_from, created_at)
+	SELECT c.id, 1100, '2022-04-01 00:00:00+07', NOW()
+	FROM tax_classes c
+	WHERE c.name = 'PPN'
+	AND NOT EXISTS (SELECT 1 FROM tax_rates r WHERE r.tax_class_id = c.id)`,
+}
@@ -6,8 +6,8 @@ import (
 	"os"
 
 	msg "product-manager/constant/messages"
-	log_util "product-manager/utils/logger"
 	"product-manager/entities"
+	log_util "product-manager/utils/logger"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -54,5 +54,34 @@ func ConnectDB(config Config) *gorm.DB {
 }
 
 func migrate(db *gorm.DB) {
-	db.AutoMigrate(&entities.Product{}, &entities.Admin{})
+	err := db.AutoMigrate(
+		&entities.Category{},
+		&entities.TaxClass{},
+		&entities.Product{},
+		&entities.Admin{},
+		&entities.ProductAudit{},
+		&entities.StockMovement{},
+		&entities.CostLayer{},
+		&entities.CostLayerConsumption{},
+		&entities.ProductVariant{},
+		&entities.ProductImage{},
+		&entities.ProductPrice{},
+		&entities.TaxRate{},
+		&entities.ProductCurrencyPrice{},
+		&entities.ExchangeRate{},
+		&entities.Promotion{},
+		&entities.WebhookSubscription{},
+		&entities.WebhookDelivery{},
+		&entities.WebhookAttempt{},
+		&entities.OutboxEvent{},
+	)
+	if err != nil {
+		log.Fatal(msg.FAILED_MIGRATE_DB, err)
+	}
+
+	for _, statement := range sqlMigrations {
+		if err := db.Exec(statement).Error; err != nil {
+			log.Fatal(msg.FAILED_MIGRATE_DB, err)
+		}
+	}
 }
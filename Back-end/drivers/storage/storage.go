@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+type Config struct {
+	STORAGE_DRIVER     string
+	STORAGE_LOCAL_ROOT string
+	STORAGE_PUBLIC_URL string
+}
+
+// Storage keeps uploaded objects under slash-separated keys such as
+// "products/12/photo.jpg". Implementations must be safe for concurrent use.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// NewStorage builds the backend named by STORAGE_DRIVER, defaulting to the
+// local filesystem.
+func NewStorage(config Config) (Storage, error) {
+	switch config.STORAGE_DRIVER {
+	case "", "local":
+		root := config.STORAGE_LOCAL_ROOT
+		if root == "" {
+			root = "uploads"
+		}
+		publicURL := config.STORAGE_PUBLIC_URL
+		if publicURL == "" {
+			publicURL = "/uploads"
+		}
+		return NewLocalStorage(root, publicURL)
+	}
+	return nil, fmt.Errorf("unsupported storage driver %q", config.STORAGE_DRIVER)
+}
@@ -7,8 +7,9 @@ type AdminRequest struct {
 }
 
 type AdminResponse struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Token    string `json:"token"`
+	ID          string   `json:"id"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email"`
+	Token       string   `json:"token"`
+	Permissions []string `json:"permissions"`
 }
\ No newline at end of file
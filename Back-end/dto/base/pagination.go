@@ -1,9 +1,17 @@
 package dto
 
+import "net/url"
+
 type PaginationRequest struct {
 	Limit  int    `json:"limit" query:"limit" validate:"required,gt=0"`
 	Page   int    `json:"page" query:"page" validate:"required,gt=0"`
 	SortBy string `json:"sort_by" query:"sort_by,omitempty"`
+	// Cursor switches to keyset pagination when set; an empty cursor asks
+	// for the first page. Page is ignored in that mode.
+	Cursor *string `json:"cursor" query:"cursor"`
+	// Query is the request's query string, which cursor links carry over so
+	// the next page keeps the same sort and filters.
+	Query url.Values `json:"-" query:"-"`
 }
 
 type PaginationResponse struct {
@@ -13,8 +21,10 @@ type PaginationResponse struct {
 }
 
 type Link struct {
-	Next string `json:"next,omitempty"`
-	Prev string `json:"prev,omitempty"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 type PaginationMetadata struct {
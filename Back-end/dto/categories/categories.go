@@ -0,0 +1,47 @@
+package categories
+
+import "time"
+
+// CategoryRequest creates or renames a category. ParentID is only read on
+// create; an existing category changes parent through CategoryMoveRequest.
+// The reorder settings are the defaults for products that set none.
+type CategoryRequest struct {
+	Name            string `json:"name" validate:"required,max=255"`
+	Slug            string `json:"slug" validate:"omitempty,max=255"`
+	ParentID        *uint  `json:"parent_id" validate:"omitempty,gt=0"`
+	ReorderPoint    *uint  `json:"reorder_point"`
+	ReorderQuantity *uint  `json:"reorder_quantity" validate:"omitempty,gt=0"`
+}
+
+// CategoryMoveRequest places a category under ParentID, or at the root when
+// it is null. Position is zero-based among the new siblings; when omitted the
+// category goes last.
+type CategoryMoveRequest struct {
+	ParentID *uint `json:"parent_id" validate:"omitempty,gt=0"`
+	Position *int  `json:"position" validate:"omitempty,gte=0"`
+}
+
+type CategoryResponse struct {
+	ID              uint      `json:"id"`
+	Name            string    `json:"name"`
+	Slug            string    `json:"slug"`
+	ParentID        *uint     `json:"parent_id"`
+	Path            string    `json:"path"`
+	Position        int       `json:"position"`
+	ReorderPoint    *uint     `json:"reorder_point"`
+	ReorderQuantity *uint     `json:"reorder_quantity"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// CategoryTreeNode counts live products assigned to the node itself and to
+// the node together with all of its descendants.
+type CategoryTreeNode struct {
+	ID                 uint               `json:"id"`
+	Name               string             `json:"name"`
+	Slug               string             `json:"slug"`
+	Position           int                `json:"position"`
+	DirectProductCount int64              `json:"direct_product_count"`
+	TotalProductCount  int64              `json:"total_product_count"`
+	Children           []CategoryTreeNode `json:"children"`
+}
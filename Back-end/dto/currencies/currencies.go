@@ -0,0 +1,19 @@
+package currencies
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ExchangeRateRequest takes the rate as a JSON number or a decimal string;
+// a string keeps every digit.
+type ExchangeRateRequest struct {
+	Rate json.Number `json:"rate" validate:"required"`
+}
+
+type ExchangeRateResponse struct {
+	Base      string    `json:"base"`
+	Quote     string    `json:"quote"`
+	Rate      string    `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
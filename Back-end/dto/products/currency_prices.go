@@ -0,0 +1,34 @@
+package products
+
+import (
+	"product-manager/utils/money"
+	"time"
+)
+
+type CurrencyPriceRequest struct {
+	// Amount is in the minor unit of the currency, e.g. cents
+	Amount int64 `json:"amount" validate:"required,gt=0"`
+}
+
+type CurrencyPriceResponse struct {
+	ProductID uint        `json:"product_id"`
+	Price     money.Money `json:"price"`
+	Formatted string      `json:"formatted"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+const (
+	DisplayPriceBase      = "base"
+	DisplayPriceList      = "price_list"
+	DisplayPriceConverted = "converted"
+)
+
+// DisplayPrice is a price after promotions in the currency a read asked
+// for. Source tells whether it is the base price, the product's price in
+// that currency, or the base price converted at Rate.
+type DisplayPrice struct {
+	money.Money
+	Formatted string `json:"formatted"`
+	Source    string `json:"source"`
+	Rate      string `json:"rate,omitempty"`
+}
@@ -0,0 +1,45 @@
+package products
+
+import "product-manager/utils/money"
+
+const (
+	FacetCategory = "category"
+	FacetPrice    = "price"
+	FacetStock    = "stock"
+)
+
+// ProductFacets count the products matching a list request. Each facet
+// ignores its own filter so a client can offer the other choices next to
+// the selected ones.
+type ProductFacets struct {
+	Categories []CategoryFacet `json:"categories,omitempty"`
+	Price      *PriceFacet     `json:"price,omitempty"`
+	Stock      *StockFacet     `json:"stock,omitempty"`
+}
+
+type CategoryFacet struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Count int64  `json:"count"`
+}
+
+// PriceFacet splits the price range into buckets of BucketWidth; each bucket
+// covers [From, To).
+type PriceFacet struct {
+	Min         money.Money   `json:"min"`
+	Max         money.Money   `json:"max"`
+	BucketWidth money.Money   `json:"bucket_width"`
+	Buckets     []PriceBucket `json:"buckets"`
+}
+
+type PriceBucket struct {
+	From  money.Money `json:"from"`
+	To    money.Money `json:"to"`
+	Count int64       `json:"count"`
+}
+
+type StockFacet struct {
+	InStock    int64 `json:"in_stock"`
+	OutOfStock int64 `json:"out_of_stock"`
+}
@@ -0,0 +1,28 @@
+package products
+
+import "time"
+
+// ProductImageUploadOptions are the form fields sent next to the image file.
+// Position is zero-based; when omitted the image goes last.
+type ProductImageUploadOptions struct {
+	Position  *int
+	IsPrimary bool
+}
+
+type ProductImageUpdateRequest struct {
+	Position  *int  `json:"position" validate:"omitempty,gte=0"`
+	IsPrimary *bool `json:"is_primary"`
+}
+
+type ProductImageResponse struct {
+	ID          uint              `json:"id"`
+	URL         string            `json:"url"`
+	Thumbnails  map[string]string `json:"thumbnails"`
+	ContentType string            `json:"content_type"`
+	Size        int64             `json:"size"`
+	Width       int               `json:"width"`
+	Height      int               `json:"height"`
+	Position    int               `json:"position"`
+	IsPrimary   bool              `json:"is_primary"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
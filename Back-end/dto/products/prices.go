@@ -0,0 +1,30 @@
+package products
+
+import (
+	"product-manager/utils/money"
+	"time"
+)
+
+// ProductPriceRequest schedules Price from EffectiveFrom until EffectiveTo,
+// after which the price that was due then comes back. Without EffectiveTo
+// the price holds until the next price already scheduled after it, if any.
+type ProductPriceRequest struct {
+	Price         money.Money `json:"price"`
+	EffectiveFrom *time.Time  `json:"effective_from" validate:"required"`
+	EffectiveTo   *time.Time  `json:"effective_to"`
+	Reason        string      `json:"reason" validate:"max=255"`
+}
+
+type ProductPriceResponse struct {
+	ID            uint        `json:"id"`
+	ProductID     uint        `json:"product_id"`
+	Price         money.Money `json:"price"`
+	EffectiveFrom time.Time   `json:"effective_from"`
+	EffectiveTo   *time.Time  `json:"effective_to"`
+	Status        string      `json:"status"`
+	Reason        string      `json:"reason"`
+	AdminID       string      `json:"admin_id"`
+	AdminUsername string      `json:"admin_username"`
+	AppliedAt     *time.Time  `json:"applied_at"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
@@ -1,45 +1,302 @@
 package products
 
 import (
-	"time"
+	"encoding/json"
+	"fmt"
 	dto_base "product-manager/dto/base"
+	"product-manager/utils/money"
+	"time"
+
+	"github.com/google/uuid"
 )
 
+// ProductRequest names its category either by category_id or by category,
+// which is matched against category slugs. Reorder settings left out fall
+// back to the category's. Sent as a form, price is the bare amount in the
+// base currency.
 type ProductRequest struct {
-	Name     string `json:"name" form:"name" validate:"required"`
-	Category string `json:"category" form:"category" validate:"required"`
-	Price    uint    `json:"price" form:"price" validate:"required"`
-	Stock    uint    `json:"stock" form:"stock" validate:"required"`
+	Name            string      `json:"name" form:"name" validate:"required"`
+	Category        string      `json:"category" form:"category" validate:"required_without=CategoryID"`
+	CategoryID      *uint       `json:"category_id" form:"category_id" validate:"omitempty,gt=0"`
+	Price           money.Money `json:"price" form:"price"`
+	Stock           *uint       `json:"stock" form:"stock" validate:"required"`
+	TaxClassID      *uint       `json:"tax_class_id" form:"tax_class_id" validate:"omitempty,gt=0"`
+	ReorderPoint    *uint       `json:"reorder_point" form:"reorder_point"`
+	ReorderQuantity *uint       `json:"reorder_quantity" form:"reorder_quantity" validate:"omitempty,gt=0"`
 }
 
+// ProductPatchRequest is a JSON Merge Patch (RFC 7396) document. Members that
+// are absent stay untouched; members that are present, zero values included,
+// are applied. The reorder settings may be null, which hands them back to the
+// category default, and so may the tax class, which leaves the product
+// untaxed.
+type ProductPatchRequest struct {
+	Name            *string      `json:"name" validate:"omitempty,min=1"`
+	Category        *string      `json:"category" validate:"omitempty,min=1"`
+	CategoryID      *uint        `json:"category_id" validate:"omitempty,gt=0"`
+	Price           *money.Money `json:"price"`
+	Stock           *uint        `json:"stock"`
+	TaxClassID      **uint       `json:"tax_class_id" validate:"omitempty,gt=0"`
+	ReorderPoint    **uint       `json:"reorder_point"`
+	ReorderQuantity **uint       `json:"reorder_quantity" validate:"omitempty,gt=0"`
+}
+
+func (p *ProductPatchRequest) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	for key, raw := range members {
+		var target any
+		nullable := false
+		switch key {
+		case "name":
+			target = &p.Name
+		case "category":
+			target = &p.Category
+		case "category_id":
+			target = &p.CategoryID
+		case "price":
+			target = &p.Price
+		case "stock":
+			target = &p.Stock
+		case "tax_class_id":
+			p.TaxClassID = new(*uint)
+			target, nullable = p.TaxClassID, true
+		case "reorder_point":
+			p.ReorderPoint = new(*uint)
+			target, nullable = p.ReorderPoint, true
+		case "reorder_quantity":
+			p.ReorderQuantity = new(*uint)
+			target, nullable = p.ReorderQuantity, true
+		default:
+			return fmt.Errorf("unknown field %s", key)
+		}
+
+		// The other product fields are mandatory, so a null member asking
+		// to remove one can never be applied.
+		if string(raw) == "null" && !nullable {
+			return fmt.Errorf("%s cannot be removed", key)
+		}
 
+		if err := json.Unmarshal(raw, target); err != nil {
+			return fmt.Errorf("invalid value for %s", key)
+		}
+	}
 
+	return nil
+}
+
+// ProductSearchFilter matches products in any of the listed categories,
+// named by ID or slug. IncludeDescendants widens each to every category
+// below it. Conditions come from filter[field][op] parameters and must all
+// hold. Facets names the aggregations to return alongside the page, and
+// Currency a currency to show prices in besides the base one.
 type ProductSearchFilter struct {
-	Name     string `json:"name"`
-	Category string `json:"category"`
-	MinPrice *uint  `json:"min_price"`
-	MaxPrice *uint  `json:"max_price"`
-	InStock  *bool  `json:"in_stock"`
+	Query              string            `json:"q"`
+	Name               string            `json:"name"`
+	CategoryIDs        []uint            `json:"category_ids"`
+	CategorySlugs      []string          `json:"category_slugs"`
+	IncludeDescendants bool              `json:"include_descendants"`
+	SKU                string            `json:"sku"`
+	VariantInStock     *bool             `json:"variant_in_stock"`
+	MinPrice           *uint             `json:"min_price"`
+	MaxPrice           *uint             `json:"max_price"`
+	InStock            *bool             `json:"in_stock"`
+	Currency           string            `json:"currency"`
+	Conditions         []FilterCondition `json:"conditions"`
+	Facets             []string          `json:"facets"`
 }
 
+const (
+	FilterEq       = "eq"
+	FilterNe       = "ne"
+	FilterLt       = "lt"
+	FilterLte      = "lte"
+	FilterGt       = "gt"
+	FilterGte      = "gte"
+	FilterIn       = "in"
+	FilterContains = "contains"
+	FilterBetween  = "between"
+)
+
+// FilterCondition is one filter[field][op] parameter. Values holds the
+// comma-separated items for in and between, and a single value otherwise.
+type FilterCondition struct {
+	Field  string   `json:"field"`
+	Op     string   `json:"op"`
+	Values []string `json:"values"`
+}
+
+// Key spells the condition the way it was given in the query string.
+func (f FilterCondition) Key() string {
+	return fmt.Sprintf("filter[%s][%s]", f.Field, f.Op)
+}
+
+// ProductResponse gives its prices in the base currency; DisplayPrice
+// carries the price in the currency a read asked for.
 type ProductResponse struct {
-    ID        uint      `json:"id"`
-    Name      string    `json:"name"`
-    Category  string    `json:"category"`
-    Price     uint      `json:"price"`
-    Stock     uint      `json:"stock"`
-    CreatedAt time.Time `json:"created_at"`
-    UpdatedAt time.Time `json:"updated_at"`
+	ID              uint        `json:"id"`
+	Name            string      `json:"name"`
+	Category        string      `json:"category"`
+	CategoryID      *uint       `json:"category_id"`
+	Price           money.Money `json:"price"`
+	BasePrice       money.Money `json:"base_price"`
+	EffectivePrice  money.Money `json:"effective_price"`
+	Stock           uint        `json:"stock"`
+	TaxClassID      *uint       `json:"tax_class_id"`
+	ReorderPoint    *uint       `json:"reorder_point"`
+	ReorderQuantity *uint       `json:"reorder_quantity"`
+	Version         uint        `json:"version"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+	DeletedAt       *time.Time  `json:"deleted_at,omitempty"`
+
+	Promotions   []AppliedPromotion       `json:"promotions,omitempty"`
+	Tax          *TaxBreakdown            `json:"tax,omitempty"`
+	CostPrice    *money.Money             `json:"cost_price,omitempty"`
+	DisplayPrice *DisplayPrice            `json:"display_price,omitempty"`
+	Availability *ProductAvailability     `json:"availability,omitempty"`
+	Variants     []ProductVariantResponse `json:"variants,omitempty"`
+	Images       []ProductImageResponse   `json:"images,omitempty"`
+
+	Relevance  *float64           `json:"relevance,omitempty"`
+	Highlights *ProductHighlights `json:"highlights,omitempty"`
+}
+
+// AppliedPromotion is a promotion that went into a product's effective
+// price, with the amount it took off. Value is set for percentage
+// promotions and Amount for fixed ones.
+type AppliedPromotion struct {
+	ID       uint         `json:"id"`
+	Name     string       `json:"name"`
+	Type     string       `json:"type"`
+	Value    uint         `json:"value,omitempty"`
+	Amount   *money.Money `json:"amount,omitempty"`
+	Discount money.Money  `json:"discount"`
+}
+
+// TaxBreakdown splits a price into its amounts before and after tax. Rate
+// is in basis points, and PricesIncludeTax tells which of Net and Gross is
+// the price as stored.
+type TaxBreakdown struct {
+	Rate             uint        `json:"rate"`
+	PricesIncludeTax bool        `json:"prices_include_tax"`
+	Net              money.Money `json:"net"`
+	Tax              money.Money `json:"tax"`
+	Gross            money.Money `json:"gross"`
+}
+
+// ProductHighlights hold HTML-escaped text with search matches wrapped in
+// <mark> elements.
+type ProductHighlights struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
 }
 
 type ProductListResponse struct {
 	dto_base.BaseResponse
-    Data       []ProductResponse              `json:"data"`
-    Pagination *dto_base.PaginationMetadata   `json:"pagination"`
+	Data       []ProductResponse            `json:"data"`
+	Pagination *dto_base.PaginationMetadata `json:"pagination"`
 }
 
 type ProductListResponseWithLinks struct {
-    Data       []ProductResponse              `json:"data"`
-    Pagination *dto_base.PaginationMetadata   `json:"pagination"`
-    Links      *dto_base.Link                 `json:"links"`
-}
\ No newline at end of file
+	Data       []ProductResponse            `json:"data"`
+	Pagination *dto_base.PaginationMetadata `json:"pagination"`
+	Facets     *ProductFacets               `json:"facets,omitempty"`
+	Links      *dto_base.Link               `json:"links"`
+}
+
+type ProductAuditFilter struct {
+	AdminID *uuid.UUID `json:"admin_id"`
+	Action  string     `json:"action"`
+	From    *time.Time `json:"from"`
+	To      *time.Time `json:"to"`
+}
+
+type FieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type ProductAuditResponse struct {
+	ID            uint                   `json:"id"`
+	ProductID     uint                   `json:"product_id"`
+	Action        string                 `json:"action"`
+	AdminID       string                 `json:"admin_id"`
+	AdminUsername string                 `json:"admin_username"`
+	Changes       map[string]FieldChange `json:"changes"`
+	CreatedAt     time.Time              `json:"created_at"`
+}
+
+type ProductAuditListResponse struct {
+	Data       []ProductAuditResponse       `json:"data"`
+	Pagination *dto_base.PaginationMetadata `json:"pagination"`
+	Links      *dto_base.Link               `json:"links"`
+}
+
+const (
+	BulkModeAtomic     = "atomic"
+	BulkModeBestEffort = "best_effort"
+
+	BulkOpCreate = "create"
+	BulkOpUpdate = "update"
+	BulkOpDelete = "delete"
+
+	BulkStatusSucceeded  = "succeeded"
+	BulkStatusFailed     = "failed"
+	BulkStatusRolledBack = "rolled_back"
+	BulkStatusSkipped    = "skipped"
+)
+
+type BulkProductRequest struct {
+	Mode       string                 `json:"mode" validate:"required,oneof=atomic best_effort"`
+	Operations []BulkProductOperation `json:"operations" validate:"required,min=1,max=1000,dive"`
+}
+
+type BulkProductOperation struct {
+	Op      string          `json:"op" validate:"required,oneof=create update delete"`
+	ID      uint            `json:"id" validate:"required_unless=Op create"`
+	Version *uint           `json:"version"`
+	Data    *ProductRequest `json:"data" validate:"required_unless=Op delete"`
+}
+
+type BulkProductResult struct {
+	Index  int              `json:"index"`
+	Op     string           `json:"op"`
+	ID     uint             `json:"id,omitempty"`
+	Status string           `json:"status"`
+	Error  string           `json:"error,omitempty"`
+	Data   *ProductResponse `json:"data,omitempty"`
+}
+
+type BulkProductResponse struct {
+	Mode      string              `json:"mode"`
+	Committed bool                `json:"committed"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Results   []BulkProductResult `json:"results"`
+}
+
+type ProductImportOptions struct {
+	DryRun bool `json:"dry_run"`
+	Upsert bool `json:"upsert"`
+}
+
+type ProductImportRowError struct {
+	Row    int      `json:"row"`
+	Name   string   `json:"name,omitempty"`
+	Errors []string `json:"errors"`
+}
+
+type ProductImportReport struct {
+	DryRun          bool                    `json:"dry_run"`
+	Upsert          bool                    `json:"upsert"`
+	TotalRows       int                     `json:"total_rows"`
+	Created         int                     `json:"created"`
+	Updated         int                     `json:"updated"`
+	Failed          int                     `json:"failed"`
+	Errors          []ProductImportRowError `json:"errors"`
+	ErrorsTruncated bool                    `json:"errors_truncated"`
+}
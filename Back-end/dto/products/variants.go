@@ -0,0 +1,39 @@
+package products
+
+import (
+	"product-manager/utils/money"
+	"time"
+)
+
+type ProductVariantRequest struct {
+	SKU     string            `json:"sku" validate:"required,max=100"`
+	Options map[string]string `json:"options" validate:"required,min=1"`
+	Price   *money.Money      `json:"price"`
+	Stock   *uint             `json:"stock" validate:"required"`
+}
+
+// ProductVariantResponse has EffectivePrice as the variant's own price or
+// else the product's, and SalePrice as that less the product's promotions.
+type ProductVariantResponse struct {
+	ID             uint              `json:"id"`
+	ProductID      uint              `json:"product_id"`
+	SKU            string            `json:"sku"`
+	Options        map[string]string `json:"options"`
+	Price          *money.Money      `json:"price"`
+	EffectivePrice money.Money       `json:"effective_price"`
+	SalePrice      money.Money       `json:"sale_price"`
+	DisplayPrice   *DisplayPrice     `json:"display_price,omitempty"`
+	Tax            *TaxBreakdown     `json:"tax,omitempty"`
+	Stock          uint              `json:"stock"`
+	InStock        bool              `json:"in_stock"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// ProductAvailability sums variant stock for products that have variants
+// and falls back to the product's own stock for those that do not.
+type ProductAvailability struct {
+	TotalStock   uint `json:"total_stock"`
+	InStock      bool `json:"in_stock"`
+	VariantCount int  `json:"variant_count"`
+}
@@ -0,0 +1,42 @@
+package promotions
+
+import (
+	"product-manager/utils/money"
+	"time"
+)
+
+// PromotionRequest creates or replaces a promotion. Percentage promotions
+// take Value in basis points and fixed ones Amount, in the base currency.
+// TargetIDs are product or category IDs as Scope says, and are left out for
+// scope all.
+type PromotionRequest struct {
+	Name        string       `json:"name" validate:"required,max=255"`
+	Description string       `json:"description" validate:"max=255"`
+	Type        string       `json:"type" validate:"required,oneof=percentage fixed"`
+	Value       uint         `json:"value" validate:"required_if=Type percentage"`
+	Amount      *money.Money `json:"amount" validate:"required_if=Type fixed"`
+	Scope       string       `json:"scope" validate:"required,oneof=all products categories"`
+	TargetIDs   []uint       `json:"target_ids" validate:"dive,gt=0"`
+	Priority    int          `json:"priority"`
+	Stacking    string       `json:"stacking" validate:"omitempty,oneof=stackable exclusive"`
+	StartsAt    *time.Time   `json:"starts_at" validate:"required"`
+	EndsAt      *time.Time   `json:"ends_at" validate:"required"`
+}
+
+type PromotionResponse struct {
+	ID          uint         `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Type        string       `json:"type"`
+	Value       uint         `json:"value,omitempty"`
+	Amount      *money.Money `json:"amount,omitempty"`
+	Scope       string       `json:"scope"`
+	TargetIDs   []uint       `json:"target_ids"`
+	Priority    int          `json:"priority"`
+	Stacking    string       `json:"stacking"`
+	StartsAt    time.Time    `json:"starts_at"`
+	EndsAt      time.Time    `json:"ends_at"`
+	Status      string       `json:"status"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
@@ -0,0 +1,57 @@
+package reports
+
+import "product-manager/utils/money"
+
+type CostPriceRequest struct {
+	CostPrice money.Money `json:"cost_price"`
+}
+
+// MarginFilter keeps the products whose margin, in percent, lies within the
+// given bounds.
+type MarginFilter struct {
+	MinMargin *float64 `json:"min_margin"`
+	MaxMargin *float64 `json:"max_margin"`
+}
+
+// ProductMargin measures a product's list price, net of tax, against its
+// cost price. MarginPercent is gross profit over the net price and
+// MarkupPercent gross profit over the cost.
+type ProductMargin struct {
+	ProductID     uint        `json:"product_id"`
+	Name          string      `json:"name"`
+	CategoryID    *uint       `json:"category_id"`
+	Category      string      `json:"category"`
+	Price         money.Money `json:"price"`
+	NetPrice      money.Money `json:"net_price"`
+	CostPrice     money.Money `json:"cost_price"`
+	GrossProfit   money.Money `json:"gross_profit"`
+	MarginPercent *float64    `json:"margin_percent"`
+	MarkupPercent *float64    `json:"markup_percent"`
+}
+
+// MarginTotals adds up one unit of each product it covers, so every product
+// weighs in by its price regardless of how much of it is in stock.
+type MarginTotals struct {
+	ProductCount  int         `json:"product_count"`
+	NetPrice      money.Money `json:"net_price"`
+	CostPrice     money.Money `json:"cost_price"`
+	GrossProfit   money.Money `json:"gross_profit"`
+	MarginPercent *float64    `json:"margin_percent"`
+	MarkupPercent *float64    `json:"markup_percent"`
+}
+
+type CategoryMargin struct {
+	CategoryID *uint  `json:"category_id"`
+	Category   string `json:"category"`
+	MarginTotals
+}
+
+// MarginReport covers the products that have a cost price; WithoutCost
+// counts those matching the filters that were left out for lacking one.
+type MarginReport struct {
+	PricesIncludeTax bool             `json:"prices_include_tax"`
+	Products         []ProductMargin  `json:"products"`
+	Categories       []CategoryMargin `json:"categories"`
+	Total            MarginTotals     `json:"total"`
+	WithoutCost      int              `json:"without_cost"`
+}
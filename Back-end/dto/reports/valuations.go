@@ -0,0 +1,41 @@
+package reports
+
+import (
+	"product-manager/utils/money"
+	"time"
+)
+
+// ProductValuation is the stock of a product on hand at the valuation time
+// and its cost. UncostedQuantity is stock received without a known cost,
+// which is left out of Quantity and Value.
+type ProductValuation struct {
+	ProductID        uint        `json:"product_id"`
+	Name             string      `json:"name"`
+	CategoryID       *uint       `json:"category_id"`
+	Category         string      `json:"category"`
+	Quantity         uint        `json:"quantity"`
+	UnitCost         *float64    `json:"unit_cost"`
+	Value            money.Money `json:"value"`
+	UncostedQuantity uint        `json:"uncosted_quantity"`
+}
+
+type ValuationTotals struct {
+	ProductCount     int         `json:"product_count"`
+	Quantity         uint        `json:"quantity"`
+	Value            money.Money `json:"value"`
+	UncostedQuantity uint        `json:"uncosted_quantity"`
+}
+
+type CategoryValuation struct {
+	CategoryID *uint  `json:"category_id"`
+	Category   string `json:"category"`
+	ValuationTotals
+}
+
+type InventoryValuation struct {
+	AsOf       time.Time           `json:"as_of"`
+	Method     string              `json:"method"`
+	Products   []ProductValuation  `json:"products"`
+	Categories []CategoryValuation `json:"categories"`
+	Total      ValuationTotals     `json:"total"`
+}
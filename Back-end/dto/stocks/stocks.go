@@ -0,0 +1,70 @@
+package stocks
+
+import (
+	dto_base "product-manager/dto/base"
+	"product-manager/utils/money"
+	"time"
+)
+
+// StockMovementRequest takes a unit cost on receipts only, and only from
+// callers with the finance permission; it is folded into the product's
+// cost price. VariantID is required for products with variants, whose stock
+// is kept by variant.
+type StockMovementRequest struct {
+	VariantID *uint        `json:"variant_id" validate:"omitempty,gt=0"`
+	Type      string       `json:"type" validate:"required,oneof=receipt sale adjustment return damage"`
+	Quantity  int          `json:"quantity" validate:"required"`
+	Reason    string       `json:"reason" validate:"required,max=255"`
+	Reference string       `json:"reference" validate:"max=255"`
+	UnitCost  *money.Money `json:"unit_cost"`
+}
+
+type StockMovementResponse struct {
+	ID            uint         `json:"id"`
+	ProductID     uint         `json:"product_id"`
+	VariantID     *uint        `json:"variant_id,omitempty"`
+	Type          string       `json:"type"`
+	Quantity      int          `json:"quantity"`
+	StockAfter    uint         `json:"stock_after"`
+	UnitCost      *money.Money `json:"unit_cost,omitempty"`
+	Reason        string       `json:"reason"`
+	Reference     string       `json:"reference"`
+	AdminID       string       `json:"admin_id"`
+	AdminUsername string       `json:"admin_username"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type StockMovementListResponse struct {
+	Data       []StockMovementResponse      `json:"data"`
+	Pagination *dto_base.PaginationMetadata `json:"pagination"`
+	Links      *dto_base.Link               `json:"links"`
+}
+
+// StockReconciliationResponse compares all the stock a product holds, its
+// own plus its variants', with its ledger.
+type StockReconciliationResponse struct {
+	ProductID   uint  `json:"product_id"`
+	CachedStock uint  `json:"cached_stock"`
+	LedgerStock int64 `json:"ledger_stock"`
+	Difference  int64 `json:"difference"`
+	Consistent  bool  `json:"consistent"`
+}
+
+// LowStockResponse reports a product at or below its reorder point. The
+// reorder settings are the effective ones, taken from the category when the
+// product sets none; Shortfall is how far below the reorder point it is.
+type LowStockResponse struct {
+	ProductID       uint   `json:"product_id"`
+	Name            string `json:"name"`
+	CategoryID      *uint  `json:"category_id"`
+	Stock           uint   `json:"stock"`
+	ReorderPoint    uint   `json:"reorder_point"`
+	ReorderQuantity *uint  `json:"reorder_quantity"`
+	Shortfall       uint   `json:"shortfall"`
+}
+
+type LowStockListResponse struct {
+	Data       []LowStockResponse           `json:"data"`
+	Pagination *dto_base.PaginationMetadata `json:"pagination"`
+	Links      *dto_base.Link               `json:"links"`
+}
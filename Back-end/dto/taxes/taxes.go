@@ -0,0 +1,33 @@
+package taxes
+
+import "time"
+
+type TaxClassRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=255"`
+}
+
+// TaxRateRequest adds a rate to a class from EffectiveFrom on. Rate is in
+// basis points, so PPN at 11% is 1100, and 0 makes the class zero-rated.
+type TaxRateRequest struct {
+	Rate          *uint      `json:"rate" validate:"required,lte=10000"`
+	EffectiveFrom *time.Time `json:"effective_from" validate:"required"`
+}
+
+type TaxClassResponse struct {
+	ID          uint              `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	CurrentRate *TaxRateResponse  `json:"current_rate"`
+	Rates       []TaxRateResponse `json:"rates,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+type TaxRateResponse struct {
+	ID            uint      `json:"id"`
+	TaxClassID    uint      `json:"tax_class_id"`
+	Rate          uint      `json:"rate"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	CreatedAt     time.Time `json:"created_at"`
+}
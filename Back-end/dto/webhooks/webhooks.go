@@ -0,0 +1,62 @@
+package webhooks
+
+import (
+	"encoding/json"
+	dto_base "product-manager/dto/base"
+	"time"
+)
+
+// WebhookRequest creates or replaces a subscription. A new subscription
+// without a secret gets a generated one; on update an empty secret keeps the
+// current one.
+type WebhookRequest struct {
+	URL         string   `json:"url" validate:"required,url,max=2048"`
+	Secret      string   `json:"secret" validate:"omitempty,min=16,max=255"`
+	Events      []string `json:"events" validate:"required,min=1,dive,required"`
+	Description string   `json:"description" validate:"max=255"`
+	Active      *bool    `json:"active"`
+}
+
+// WebhookResponse only carries the secret right after it was set, so it
+// can be copied to the receiving end once.
+type WebhookResponse struct {
+	ID          uint      `json:"id"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"secret,omitempty"`
+	Events      []string  `json:"events"`
+	Description string    `json:"description"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uint                     `json:"id"`
+	SubscriptionID uint                     `json:"subscription_id"`
+	EventID        string                   `json:"event_id"`
+	Event          string                   `json:"event"`
+	Payload        json.RawMessage          `json:"payload"`
+	Status         string                   `json:"status"`
+	Attempts       int                      `json:"attempts"`
+	NextAttemptAt  *time.Time               `json:"next_attempt_at"`
+	LastStatusCode *int                     `json:"last_status_code"`
+	LastError      string                   `json:"last_error"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+	AttemptLog     []WebhookAttemptResponse `json:"attempt_log,omitempty"`
+}
+
+type WebhookAttemptResponse struct {
+	Attempt      int       `json:"attempt"`
+	StatusCode   *int      `json:"status_code"`
+	ResponseBody string    `json:"response_body"`
+	Error        string    `json:"error"`
+	DurationMs   int64     `json:"duration_ms"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Data       []WebhookDeliveryResponse    `json:"data"`
+	Pagination *dto_base.PaginationMetadata `json:"pagination"`
+	Links      *dto_base.Link               `json:"links"`
+}
@@ -1,16 +1,51 @@
 package entities
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Admin struct {
-	ID        uuid.UUID `gorm:"primaryKey;autoIncrement" json:"id"`
-	Username  string    `gorm:"type:varchar(255);not null" json:"username"`
-	Password  string    `gorm:"type:varchar(255);not null" json:"password"`
-	Email     string    `gorm:"type:varchar(255);not null" json:"email"`
-	Token     string    `json:"token"`
-	CreatedAt time.Time
+	ID          uuid.UUID        `gorm:"primaryKey;autoIncrement" json:"id"`
+	Username    string           `gorm:"type:varchar(255);not null" json:"username"`
+	Password    string           `gorm:"type:varchar(255);not null" json:"password"`
+	Email       string           `gorm:"type:varchar(255);not null" json:"email"`
+	Token       string           `json:"token"`
+	Permissions AdminPermissions `gorm:"type:jsonb;not null;default:'[]'" json:"permissions"`
+	CreatedAt   time.Time
+}
+
+// AdminPermissions are granted in the database only; registering never sets
+// any.
+type AdminPermissions []string
+
+func (p AdminPermissions) Value() (driver.Value, error) {
+	if p == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (p *AdminPermissions) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*p = AdminPermissions{}
+		return nil
+	default:
+		return errors.New("unsupported type for AdminPermissions")
+	}
+	return json.Unmarshal(b, p)
 }
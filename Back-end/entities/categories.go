@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"fmt"
+	err_util "product-manager/utils/error"
+	"strings"
+	"time"
+)
+
+// Category is a node in the category tree. Path is the materialized list of
+// ancestor IDs ending with the category's own, e.g. "/1/4/9/", so a subtree
+// is every row whose path starts with the root's path.
+type Category struct {
+	ID       uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name     string    `gorm:"type:varchar(255);not null" json:"name"`
+	Slug     string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"slug"`
+	ParentID *uint     `gorm:"index" json:"parent_id"`
+	Parent   *Category `gorm:"foreignKey:ParentID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+	Path     string    `gorm:"type:varchar(1024);not null;default:''" json:"path"`
+	Position int       `gorm:"not null;default:0" json:"position"`
+	// Reorder defaults for products that set none of their own
+	ReorderPoint    *uint     `gorm:"type:int" json:"reorder_point"`
+	ReorderQuantity *uint     `gorm:"type:int" json:"reorder_quantity"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// ChildPath returns the path of a category with the given ID placed under
+// parent, or at the root when parent is nil.
+func ChildPath(parent *Category, id uint) string {
+	prefix := "/"
+	if parent != nil {
+		prefix = parent.Path
+	}
+	return fmt.Sprintf("%s%d/", prefix, id)
+}
+
+// IsDescendantOf reports whether c sits anywhere below other, or is other.
+func (c *Category) IsDescendantOf(other *Category) bool {
+	return other.Path != "" && strings.HasPrefix(c.Path, other.Path)
+}
+
+func (c *Category) IsValid() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return err_util.ErrCategoryNameRequired
+	}
+	if c.Slug == "" || Slugify(c.Slug) != c.Slug {
+		return err_util.ErrInvalidCategorySlug
+	}
+	return nil
+}
+
+// Slugify lowercases s and collapses every run of characters outside a-z and
+// 0-9 into a single hyphen. The category backfill migration mirrors this in
+// SQL, so the two must stay in step.
+func Slugify(s string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+			continue
+		}
+		pendingHyphen = true
+	}
+	return b.String()
+}
@@ -0,0 +1,145 @@
+package entities
+
+import (
+	"cmp"
+	"product-manager/utils/money"
+	"slices"
+	"time"
+)
+
+const (
+	ValuationFIFO            = "fifo"
+	ValuationWeightedAverage = "weighted_average"
+)
+
+// CostLayer is stock that came in through one inbound movement at one unit
+// cost. Outbound movements consume the oldest layers of the same product, or
+// variant when VariantID is set, first, and Remaining is what is still on
+// hand. A nil UnitCost marks stock that came in before its cost was known.
+type CostLayer struct {
+	ID         uint         `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID  uint         `gorm:"not null;index:idx_cost_layers_product_received,priority:1" json:"product_id"`
+	Product    *Product     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	VariantID  *uint        `gorm:"index" json:"variant_id"`
+	MovementID uint         `gorm:"not null;index" json:"movement_id"`
+	UnitCost   *money.Money `gorm:"serializer:base_money;type:bigint" json:"unit_cost"`
+	Quantity   uint         `gorm:"type:int;not null" json:"quantity"`
+	Remaining  uint         `gorm:"type:int;not null" json:"remaining"`
+	ReceivedAt time.Time    `gorm:"not null;index:idx_cost_layers_product_received,priority:2" json:"received_at"`
+	CreatedAt  time.Time    `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// CostLayerConsumption is what one outbound movement took from one layer.
+type CostLayerConsumption struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	LayerID    uint       `gorm:"not null;index" json:"layer_id"`
+	Layer      *CostLayer `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	MovementID uint       `gorm:"not null;index" json:"movement_id"`
+	Quantity   uint       `gorm:"type:int;not null" json:"quantity"`
+	ConsumedAt time.Time  `gorm:"not null;index" json:"consumed_at"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// StockValuation is the stock of one product on hand at a point in time and
+// what it is worth. Stock in layers without a cost is counted in Uncosted
+// and left out of Quantity and Value.
+type StockValuation struct {
+	Quantity uint
+	Value    money.Money
+	Uncosted uint
+}
+
+// ConsumeFIFO takes quantity from layers, oldest first, lowering their
+// Remaining and returning what came off each. Layers must be in the order
+// they were received. Stock beyond what the layers hold predates them and
+// is not consumed from anything.
+func ConsumeFIFO(layers []CostLayer, quantity uint) []CostLayerConsumption {
+	var consumptions []CostLayerConsumption
+	for i := range layers {
+		if quantity == 0 {
+			break
+		}
+		taken := min(layers[i].Remaining, quantity)
+		if taken == 0 {
+			continue
+		}
+		layers[i].Remaining -= taken
+		quantity -= taken
+		consumptions = append(consumptions, CostLayerConsumption{
+			LayerID:  layers[i].ID,
+			Quantity: taken,
+		})
+	}
+	return consumptions
+}
+
+// ValueFIFO values the layers of one product as they stood once the given
+// consumptions, all from those layers, had been taken: each unit left is
+// worth what its own layer cost.
+func ValueFIFO(layers []CostLayer, consumptions []CostLayerConsumption) StockValuation {
+	consumed := make(map[uint]uint, len(layers))
+	for _, c := range consumptions {
+		consumed[c.LayerID] += c.Quantity
+	}
+
+	var v StockValuation
+	var value int64
+	for _, l := range layers {
+		left := l.Quantity - min(consumed[l.ID], l.Quantity)
+		if l.UnitCost == nil {
+			v.Uncosted += left
+			continue
+		}
+		v.Quantity += left
+		value += int64(left) * l.UnitCost.Amount
+	}
+	v.Value = BaseMoney(value)
+	return v
+}
+
+// ValueWeightedAverage values the same stock at a moving average cost: the
+// layers and consumptions are replayed in the order they happened, each
+// receipt blending into the cost of what is on hand and each consumption
+// taking units out at that cost, rounded half up.
+func ValueWeightedAverage(layers []CostLayer, consumptions []CostLayerConsumption) StockValuation {
+	type event struct {
+		at         time.Time
+		movementID uint
+		quantity   uint
+		inbound    bool
+		unitCost   *money.Money
+	}
+
+	costOf := make(map[uint]*money.Money, len(layers))
+	events := make([]event, 0, len(layers)+len(consumptions))
+	for _, l := range layers {
+		costOf[l.ID] = l.UnitCost
+		events = append(events, event{at: l.ReceivedAt, movementID: l.MovementID, quantity: l.Quantity, inbound: true, unitCost: l.UnitCost})
+	}
+	for _, c := range consumptions {
+		events = append(events, event{at: c.ConsumedAt, movementID: c.MovementID, quantity: c.Quantity, unitCost: costOf[c.LayerID]})
+	}
+	slices.SortStableFunc(events, func(a, b event) int {
+		return cmp.Or(a.at.Compare(b.at), cmp.Compare(a.movementID, b.movementID))
+	})
+
+	var v StockValuation
+	var value uint64
+	for _, e := range events {
+		switch {
+		case e.unitCost == nil && e.inbound:
+			v.Uncosted += e.quantity
+		case e.unitCost == nil:
+			v.Uncosted -= min(e.quantity, v.Uncosted)
+		case e.inbound:
+			v.Quantity += e.quantity
+			value += uint64(e.quantity) * uint64(e.unitCost.Amount)
+		case v.Quantity > 0:
+			taken := min(e.quantity, v.Quantity)
+			value -= uint64(divRound(value*uint64(taken), uint64(v.Quantity)))
+			v.Quantity -= taken
+		}
+	}
+	v.Value = BaseMoney(int64(value))
+	return v
+}
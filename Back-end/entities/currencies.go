@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"math/big"
+	"product-manager/utils/money"
+	"time"
+)
+
+// BaseCurrency is the currency of the catalog: product, variant and
+// scheduled prices, fixed promotions and costs are all in it.
+const BaseCurrency = money.IDR
+
+// ExchangeRate is the price of one Base in Quote, both in major units. Rate
+// is kept as the exact decimal it was entered as.
+type ExchangeRate struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Base      string    `gorm:"type:char(3);not null;uniqueIndex:idx_exchange_rates_pair,priority:1" json:"base"`
+	Quote     string    `gorm:"type:char(3);not null;uniqueIndex:idx_exchange_rates_pair,priority:2" json:"quote"`
+	Rate      string    `gorm:"type:numeric(20,10);not null" json:"rate"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (r *ExchangeRate) Ratio() (*big.Rat, error) {
+	return money.ParseRate(r.Rate)
+}
+
+// ProductCurrencyPrice sets a product's price in a currency other than the
+// base one, taking precedence over converting the base price.
+type ProductCurrencyPrice struct {
+	ID        uint        `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID uint        `gorm:"not null;index" json:"product_id"`
+	Product   *Product    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Price     money.Money `gorm:"embedded;embeddedPrefix:price_" json:"price"`
+	CreatedAt time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+}
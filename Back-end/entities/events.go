@@ -0,0 +1,28 @@
+package entities
+
+import "slices"
+
+// Events recorded in the outbox, which webhook subscriptions can also listen
+// to.
+const (
+	EventProductCreated = "product.created"
+	EventProductUpdated = "product.updated"
+	EventProductDeleted = "product.deleted"
+	// EventStockChanged follows any change to a product's sellable stock
+	EventStockChanged = "stock.changed"
+	// EventStockLow follows a change that takes a product's stock to or
+	// below its reorder point
+	EventStockLow = "stock.low"
+)
+
+var eventNames = []string{
+	EventProductCreated,
+	EventProductUpdated,
+	EventProductDeleted,
+	EventStockChanged,
+	EventStockLow,
+}
+
+func IsEventName(name string) bool {
+	return slices.Contains(eventNames, name)
+}
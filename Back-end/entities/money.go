@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+	"product-manager/utils/money"
+	"reflect"
+
+	"gorm.io/gorm/schema"
+)
+
+func init() {
+	schema.RegisterSerializer("base_money", baseMoneySerializer{})
+}
+
+// BaseMoney is amount in the minor unit of the base currency.
+func BaseMoney(amount int64) money.Money {
+	return money.New(amount, BaseCurrency)
+}
+
+// baseMoneySerializer keeps a money.Money, or a *money.Money, in the base
+// currency as its bare amount, so catalog prices stay integer columns that
+// SQL can filter, sort and add up. Amounts in any other currency are turned
+// away rather than stored as if they were in the base one.
+type baseMoneySerializer struct{}
+
+func (baseMoneySerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	target := field.ReflectValueOf(ctx, dst)
+	if dbValue == nil {
+		target.Set(reflect.Zero(field.FieldType))
+		return nil
+	}
+
+	var amount int64
+	switch v := dbValue.(type) {
+	case int64:
+		amount = v
+	case int32:
+		amount = int64(v)
+	case int:
+		amount = int64(v)
+	default:
+		return fmt.Errorf("unsupported type %T for %s", dbValue, field.Name)
+	}
+
+	m := BaseMoney(amount)
+	if field.FieldType.Kind() == reflect.Pointer {
+		target.Set(reflect.ValueOf(&m))
+	} else {
+		target.Set(reflect.ValueOf(m))
+	}
+	return nil
+}
+
+func (baseMoneySerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	var m money.Money
+	switch v := fieldValue.(type) {
+	case money.Money:
+		m = v
+	case *money.Money:
+		if v == nil {
+			return nil, nil
+		}
+		m = *v
+	default:
+		return nil, fmt.Errorf("unsupported type %T for %s", fieldValue, field.Name)
+	}
+
+	if m.Currency != BaseCurrency {
+		return nil, fmt.Errorf("%s must be in %s, got %s", field.Name, BaseCurrency, m.Currency)
+	}
+	return m.Amount, nil
+}
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is an event written in the same transaction as the change it
+// describes, waiting to be handed to the sinks. ID gives the order events
+// are dispatched in. A pending event has neither DispatchedAt nor FailedAt.
+type OutboxEvent struct {
+	ID           uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"event_id"`
+	Name         string     `gorm:"type:varchar(50);not null" json:"name"`
+	Payload      string     `gorm:"type:jsonb;not null" json:"payload"`
+	OccurredAt   time.Time  `gorm:"not null" json:"occurred_at"`
+	Attempts     int        `gorm:"not null;default:0" json:"attempts"`
+	LastError    string     `gorm:"type:text;not null;default:''" json:"last_error"`
+	DispatchedAt *time.Time `gorm:"index" json:"dispatched_at"`
+	FailedAt     *time.Time `json:"failed_at"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+	AuditActionPurge   = "purge"
+)
+
+var AuditActions = map[string]bool{
+	AuditActionCreate:  true,
+	AuditActionUpdate:  true,
+	AuditActionDelete:  true,
+	AuditActionRestore: true,
+	AuditActionPurge:   true,
+}
+
+type ProductAudit struct {
+	ID            uint         `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID     uint         `gorm:"not null;index" json:"product_id"`
+	Action        string       `gorm:"type:varchar(20);not null;index" json:"action"`
+	AdminID       uuid.UUID    `gorm:"type:uuid;index" json:"admin_id"`
+	AdminUsername string       `gorm:"type:varchar(255)" json:"admin_username"`
+	Changes       AuditChanges `gorm:"type:jsonb;not null" json:"changes"`
+	CreatedAt     time.Time    `gorm:"autoCreateTime;index" json:"created_at"`
+}
+
+type FieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type AuditChanges map[string]FieldChange
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (c *AuditChanges) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*c = AuditChanges{}
+		return nil
+	default:
+		return errors.New("unsupported type for AuditChanges")
+	}
+	return json.Unmarshal(b, c)
+}
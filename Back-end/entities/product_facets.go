@@ -0,0 +1,27 @@
+package entities
+
+// CategoryCount is the number of products filed directly under a category.
+type CategoryCount struct {
+	CategoryID uint
+	Name       string
+	Slug       string
+	Count      int64
+}
+
+type PriceRange struct {
+	Min   uint
+	Max   uint
+	Count int64
+}
+
+// PriceBucketCount counts the products whose price falls in
+// [Bucket*width, (Bucket+1)*width) for the width that was queried.
+type PriceBucketCount struct {
+	Bucket int64
+	Count  int64
+}
+
+type StockCount struct {
+	InStock    int64
+	OutOfStock int64
+}
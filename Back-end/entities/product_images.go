@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ProductImage is an uploaded picture of a product. Key locates the original
+// in storage and Thumbnails maps each thumbnail size to its key. Positions
+// are contiguous per product and at most one image is primary.
+type ProductImage struct {
+	ID          uint            `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID   uint            `gorm:"not null;index" json:"product_id"`
+	Product     *Product        `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Key         string          `gorm:"type:varchar(512);not null" json:"key"`
+	ContentType string          `gorm:"type:varchar(50);not null" json:"content_type"`
+	Size        int64           `gorm:"not null" json:"size"`
+	Width       int             `gorm:"not null" json:"width"`
+	Height      int             `gorm:"not null" json:"height"`
+	Thumbnails  ImageThumbnails `gorm:"type:jsonb;not null" json:"thumbnails"`
+	Position    int             `gorm:"not null;default:0" json:"position"`
+	IsPrimary   bool            `gorm:"not null;default:false" json:"is_primary"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// Keys returns every storage key the image occupies.
+func (i *ProductImage) Keys() []string {
+	keys := []string{i.Key}
+	for _, key := range i.Thumbnails {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+type ImageThumbnails map[string]string
+
+func (t ImageThumbnails) Value() (driver.Value, error) {
+	if t == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (t *ImageThumbnails) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*t = ImageThumbnails{}
+		return nil
+	default:
+		return errors.New("unsupported type for ImageThumbnails")
+	}
+	return json.Unmarshal(b, t)
+}
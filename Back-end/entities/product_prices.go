@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"product-manager/utils/money"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PriceStatusPast      = "past"
+	PriceStatusCurrent   = "current"
+	PriceStatusScheduled = "scheduled"
+)
+
+// ProductPrice is the price of a product over [EffectiveFrom, EffectiveTo).
+// A product's prices form an unbroken timeline: each one ends where the
+// next begins and the last has no EffectiveTo. AppliedAt is set once the
+// price has been copied onto the product row.
+type ProductPrice struct {
+	ID            uint        `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID     uint        `gorm:"not null;index:idx_product_prices_timeline,priority:1" json:"product_id"`
+	Product       *Product    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Price         money.Money `gorm:"serializer:base_money;type:bigint;not null" json:"price"`
+	EffectiveFrom time.Time   `gorm:"not null;index:idx_product_prices_timeline,priority:2" json:"effective_from"`
+	EffectiveTo   *time.Time  `json:"effective_to"`
+	Reason        string      `gorm:"type:varchar(255);not null;default:''" json:"reason"`
+	AdminID       uuid.UUID   `gorm:"type:uuid;index" json:"admin_id"`
+	AdminUsername string      `gorm:"type:varchar(255)" json:"admin_username"`
+	AppliedAt     *time.Time  `json:"applied_at"`
+	CreatedAt     time.Time   `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// Covers reports whether the price is in effect at t.
+func (p *ProductPrice) Covers(t time.Time) bool {
+	return !p.EffectiveFrom.After(t) && (p.EffectiveTo == nil || p.EffectiveTo.After(t))
+}
+
+// Status places the price on the timeline relative to now.
+func (p *ProductPrice) Status(now time.Time) string {
+	switch {
+	case p.EffectiveFrom.After(now):
+		return PriceStatusScheduled
+	case p.Covers(now):
+		return PriceStatusCurrent
+	default:
+		return PriceStatusPast
+	}
+}
@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	err_util "product-manager/utils/error"
+	"product-manager/utils/money"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProductVariant is a sellable SKU of a product, such as one size and colour
+// of a T-shirt. OptionsKey is the canonical form of Options and keeps each
+// option set unique within its product.
+type ProductVariant struct {
+	ID         uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID  uint           `gorm:"not null;uniqueIndex:idx_product_variants_options,priority:1" json:"product_id"`
+	Product    *Product       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	SKU        string         `gorm:"column:sku;type:varchar(100);not null;uniqueIndex" json:"sku"`
+	Options    VariantOptions `gorm:"type:jsonb;not null" json:"options"`
+	OptionsKey string         `gorm:"type:varchar(1024);not null;uniqueIndex:idx_product_variants_options,priority:2" json:"-"`
+	Price      *money.Money   `gorm:"serializer:base_money;type:bigint" json:"price"`
+	Stock      uint           `gorm:"not null;default:0" json:"stock"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (v *ProductVariant) IsValid() error {
+	if strings.TrimSpace(v.SKU) == "" {
+		return err_util.ErrVariantSKURequired
+	}
+	if len(v.Options) == 0 {
+		return err_util.ErrVariantOptionsRequired
+	}
+	return nil
+}
+
+// EffectivePrice is the variant's own price, or the product price when the
+// variant does not override it.
+func (v *ProductVariant) EffectivePrice(productPrice money.Money) money.Money {
+	if v.Price != nil {
+		return *v.Price
+	}
+	return productPrice
+}
+
+// VariantOptions maps an option name to its value, e.g. size to "M".
+type VariantOptions map[string]string
+
+// Normalize trims names and values and lowercases names, so "Size" and
+// " size" name the same option.
+func (o VariantOptions) Normalize() VariantOptions {
+	normalized := make(VariantOptions, len(o))
+	for name, value := range o {
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+		if name == "" || value == "" {
+			continue
+		}
+		normalized[name] = value
+	}
+	return normalized
+}
+
+// Key renders the options sorted by name with case-folded values, so the
+// same option set always yields the same key.
+func (o VariantOptions) Key() string {
+	names := make([]string, 0, len(o))
+	for name := range o {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + "=" + strings.ToLower(o[name])
+	}
+	return strings.Join(parts, ";")
+}
+
+func (o VariantOptions) Value() (driver.Value, error) {
+	if o == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (o *VariantOptions) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*o = VariantOptions{}
+		return nil
+	default:
+		return errors.New("unsupported type for VariantOptions")
+	}
+	return json.Unmarshal(b, o)
+}
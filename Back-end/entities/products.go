@@ -2,19 +2,51 @@ package entities
 
 import (
 	err_util "product-manager/utils/error"
+	"product-manager/utils/money"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Product struct {
-	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
-	Name      string    `gorm:"type:varchar(255);not null" json:"name"`
-	Category  string    `gorm:"type:varchar(255);not null" json:"category"`
-	Price     uint      `gorm:"type:int;not null" json:"price"`
-	Stock     uint      `gorm:"type:int;not null;default:0" json:"stock"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID          uint        `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string      `gorm:"type:varchar(255);not null" json:"name"`
+	Category    string      `gorm:"type:varchar(255);not null" json:"category"`
+	CategoryID  *uint       `gorm:"index" json:"category_id"`
+	CategoryRef *Category   `gorm:"foreignKey:CategoryID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+	Price       money.Money `gorm:"serializer:base_money;type:bigint;not null" json:"price"`
+	Stock       uint        `gorm:"type:int;not null;default:0" json:"stock"`
+	// CostPrice is finance data and never leaves through the product itself
+	CostPrice *money.Money `gorm:"serializer:base_money;type:bigint" json:"-"`
+	// Products without a tax class are not taxed
+	TaxClassID  *uint     `gorm:"index" json:"tax_class_id"`
+	TaxClassRef *TaxClass `gorm:"foreignKey:TaxClassID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+	// Reorder settings left nil fall back to the category's defaults
+	ReorderPoint    *uint          `gorm:"type:int" json:"reorder_point"`
+	ReorderQuantity *uint          `gorm:"type:int" json:"reorder_quantity"`
+	Version         uint           `gorm:"type:int;not null;default:1" json:"version"`
+	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+
+	// Filled only by full-text searches; the search_vector column behind
+	// them is generated by the database and never mapped here.
+	SearchRank        *float64 `gorm:"->;-:migration" json:"-"`
+	NameHighlight     *string  `gorm:"->;-:migration" json:"-"`
+	CategoryHighlight *string  `gorm:"->;-:migration" json:"-"`
+
+	// Filled only when sorting by total stock
+	TotalStock *uint `gorm:"->;-:migration" json:"-"`
 }
 
+// Search highlights mark matched words with these private-use runes, which
+// never occur in catalog text, so callers can escape the text safely before
+// turning the marks into markup.
+const (
+	SearchHighlightStart = "\ue000"
+	SearchHighlightStop  = "\ue001"
+)
+
 func (p *Product) IsValid() error {
 	if p.Name == "" {
 		return err_util.ErrProductNameRequired
@@ -22,7 +54,7 @@ func (p *Product) IsValid() error {
 	if p.Category == "" {
 		return err_util.ErrProductCategoryRequired
 	}
-	if p.Price == 0 {
+	if p.Price.Amount <= 0 {
 		return err_util.ErrProductPriceRequired
 	}
 	return nil
@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	err_util "product-manager/utils/error"
+	"product-manager/utils/money"
+	"time"
+)
+
+const (
+	PromotionPercentage = "percentage"
+	PromotionFixed      = "fixed"
+
+	PromotionScopeAll        = "all"
+	PromotionScopeProducts   = "products"
+	PromotionScopeCategories = "categories"
+
+	// A stackable promotion applies together with other stackable ones; an
+	// exclusive one applies on its own or not at all.
+	PromotionStackable = "stackable"
+	PromotionExclusive = "exclusive"
+
+	PromotionStatusScheduled = "scheduled"
+	PromotionStatusActive    = "active"
+	PromotionStatusEnded     = "ended"
+)
+
+// maxPercentage is 100% in basis points.
+const maxPercentage = 10000
+
+// Promotion takes a share or an amount off the price of the products in its
+// scope from StartsAt until EndsAt: Value, in basis points so 1250 is 12.5%
+// off, for percentage promotions and Amount for fixed ones, each leaving the
+// other unset. TargetIDs hold product or category IDs depending on Scope, a
+// category covering its whole subtree.
+type Promotion struct {
+	ID          uint             `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string           `gorm:"type:varchar(255);not null" json:"name"`
+	Description string           `gorm:"type:varchar(255);not null;default:''" json:"description"`
+	Type        string           `gorm:"type:varchar(20);not null" json:"type"`
+	Value       uint             `gorm:"not null" json:"value"`
+	Amount      *money.Money     `gorm:"serializer:base_money;type:bigint" json:"amount"`
+	Scope       string           `gorm:"type:varchar(20);not null" json:"scope"`
+	TargetIDs   PromotionTargets `gorm:"type:jsonb;not null" json:"target_ids"`
+	Priority    int              `gorm:"not null;default:0" json:"priority"`
+	Stacking    string           `gorm:"type:varchar(20);not null;default:'stackable'" json:"stacking"`
+	StartsAt    time.Time        `gorm:"not null;index:idx_promotions_period,priority:1" json:"starts_at"`
+	EndsAt      time.Time        `gorm:"not null;index:idx_promotions_period,priority:2" json:"ends_at"`
+	CreatedAt   time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (p *Promotion) IsValid() error {
+	switch p.Type {
+	case PromotionPercentage:
+		if p.Value == 0 || p.Value > maxPercentage || p.Amount != nil {
+			return err_util.ErrInvalidPromotionValue
+		}
+	case PromotionFixed:
+		if p.Amount == nil || p.Amount.Amount <= 0 || p.Value != 0 {
+			return err_util.ErrInvalidPromotionValue
+		}
+	}
+	if !p.EndsAt.After(p.StartsAt) {
+		return err_util.ErrInvalidPromotionPeriod
+	}
+	if p.Scope == PromotionScopeAll && len(p.TargetIDs) > 0 {
+		return err_util.ErrPromotionTargetsNotAllowed
+	}
+	if p.Scope != PromotionScopeAll && len(p.TargetIDs) == 0 {
+		return err_util.ErrPromotionTargetsRequired
+	}
+	return nil
+}
+
+// Status tells where now falls in the promotion's period.
+func (p *Promotion) Status(now time.Time) string {
+	switch {
+	case now.Before(p.StartsAt):
+		return PromotionStatusScheduled
+	case now.Before(p.EndsAt):
+		return PromotionStatusActive
+	}
+	return PromotionStatusEnded
+}
+
+// Discount is what the promotion takes off price, rounding percentages half
+// up and never going below zero.
+func (p *Promotion) Discount(price money.Money) money.Money {
+	if p.Type == PromotionPercentage {
+		return money.New(int64(divRound(uint64(price.Amount)*uint64(p.Value), maxPercentage)), price.Currency)
+	}
+	return money.New(min(p.Amount.Amount, price.Amount), price.Currency)
+}
+
+type PromotionTargets []uint
+
+func (t PromotionTargets) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (t *PromotionTargets) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*t = PromotionTargets{}
+		return nil
+	default:
+		return errors.New("unsupported type for PromotionTargets")
+	}
+	return json.Unmarshal(b, t)
+}
@@ -0,0 +1,31 @@
+package entities
+
+// StockLevel is a product's sellable stock against its effective reorder
+// settings, the product's own or else its category's.
+type StockLevel struct {
+	ProductID       uint   `json:"product_id"`
+	Name            string `json:"name"`
+	CategoryID      *uint  `json:"category_id"`
+	Stock           uint   `json:"stock"`
+	ReorderPoint    *uint  `json:"reorder_point"`
+	ReorderQuantity *uint  `json:"reorder_quantity"`
+}
+
+// IsLow reports whether the stock is at or below the reorder point. Products
+// without one are never low.
+func (l *StockLevel) IsLow() bool {
+	return l.ReorderPoint != nil && l.Stock <= *l.ReorderPoint
+}
+
+// CrossedLow reports whether the change from before to l took the stock
+// from above the reorder point to at or below it.
+func (l *StockLevel) CrossedLow(before *StockLevel) bool {
+	return l.IsLow() && (before == nil || !before.IsLow())
+}
+
+// StockChange is the payload of a stock.changed event.
+type StockChange struct {
+	ProductID uint `json:"product_id"`
+	Before    uint `json:"before"`
+	After     uint `json:"after"`
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	err_util "product-manager/utils/error"
+	"product-manager/utils/money"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StockMovementReceipt    = "receipt"
+	StockMovementSale       = "sale"
+	StockMovementAdjustment = "adjustment"
+	StockMovementReturn     = "return"
+	StockMovementDamage     = "damage"
+)
+
+// StockMovement moves the stock of a product, or of one of its variants when
+// VariantID is set; StockAfter is the stock of whichever moved.
+type StockMovement struct {
+	ID            uint         `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID     uint         `gorm:"not null;index" json:"product_id"`
+	VariantID     *uint        `gorm:"index" json:"variant_id"`
+	Type          string       `gorm:"type:varchar(20);not null;index" json:"type"`
+	Quantity      int          `gorm:"type:int;not null" json:"quantity"`
+	StockAfter    uint         `gorm:"type:int;not null" json:"stock_after"`
+	UnitCost      *money.Money `gorm:"serializer:base_money;type:bigint" json:"unit_cost"`
+	Reason        string       `gorm:"type:varchar(255);not null" json:"reason"`
+	Reference     string       `gorm:"type:varchar(255);index" json:"reference"`
+	AdminID       uuid.UUID    `gorm:"type:uuid;index" json:"admin_id"`
+	AdminUsername string       `gorm:"type:varchar(255)" json:"admin_username"`
+	CreatedAt     time.Time    `gorm:"autoCreateTime;index" json:"created_at"`
+}
+
+// StockLedgerBalance compares the stock cached on a product and its variants
+// with the ledger sum.
+type StockLedgerBalance struct {
+	ProductID   uint  `json:"product_id"`
+	CachedStock uint  `json:"cached_stock"`
+	LedgerStock int64 `json:"ledger_stock"`
+}
+
+// StockDelta converts a movement quantity into the signed change it applies
+// to stock. Receipts and returns add stock, sales and damage remove it, and
+// adjustments carry their own sign.
+func StockDelta(movementType string, quantity int) (int, error) {
+	if quantity == 0 {
+		return 0, err_util.ErrInvalidStockQuantity
+	}
+
+	switch movementType {
+	case StockMovementReceipt, StockMovementReturn:
+		if quantity < 0 {
+			return 0, err_util.ErrInvalidStockQuantity
+		}
+		return quantity, nil
+	case StockMovementSale, StockMovementDamage:
+		if quantity < 0 {
+			return 0, err_util.ErrInvalidStockQuantity
+		}
+		return -quantity, nil
+	case StockMovementAdjustment:
+		return quantity, nil
+	}
+
+	return 0, err_util.ErrInvalidStockMovementType
+}
+
+// AverageCost folds quantity units received at unitCost into the cost of
+// the stock on hand, weighting each by its quantity and rounding half up.
+// Stock without a known cost takes the cost of the receipt.
+func AverageCost(stock uint, cost *money.Money, quantity uint, unitCost money.Money) money.Money {
+	if cost == nil || stock == 0 {
+		return unitCost
+	}
+	total := uint64(stock)*uint64(cost.Amount) + uint64(quantity)*uint64(unitCost.Amount)
+	return money.New(int64(divRound(total, uint64(stock)+uint64(quantity))), unitCost.Currency)
+}
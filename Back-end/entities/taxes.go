@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"product-manager/utils/money"
+	"time"
+)
+
+// basisPoints is 100% in basis points, the unit tax rates are kept in.
+const basisPoints = 10000
+
+// TaxClass groups products taxed alike, such as those under standard PPN.
+// Its rate over time is the list of its TaxRates.
+type TaxClass struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	Description string    `gorm:"type:varchar(255);not null;default:''" json:"description"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TaxRate is the rate of a tax class in basis points, 1100 being 11%, from
+// EffectiveFrom until the next rate of the class takes over.
+type TaxRate struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	TaxClassID    uint      `gorm:"not null;uniqueIndex:idx_tax_rates_class_from,priority:1" json:"tax_class_id"`
+	TaxClass      *TaxClass `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Rate          uint      `gorm:"type:int;not null" json:"rate"`
+	EffectiveFrom time.Time `gorm:"not null;uniqueIndex:idx_tax_rates_class_from,priority:2" json:"effective_from"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// ApplyTax splits price into its net, tax and gross amounts at rate. With
+// inclusive set the price is taken as gross and the net amount is backed out
+// of it; otherwise the price is net. Either way the one division is rounded
+// half up and the other amounts follow from it, so net + tax is always
+// gross.
+func ApplyTax(price money.Money, rate uint, inclusive bool) (net, tax, gross money.Money) {
+	amount := uint64(price.Amount)
+	if inclusive {
+		gross = price
+		net = money.New(int64(divRound(amount*basisPoints, uint64(basisPoints+rate))), price.Currency)
+		return net, money.New(gross.Amount-net.Amount, price.Currency), gross
+	}
+	net = price
+	tax = money.New(int64(divRound(amount*uint64(rate), basisPoints)), price.Currency)
+	return net, tax, money.New(net.Amount+tax.Amount, price.Currency)
+}
+
+// divRound divides n by d rounding half up.
+func divRound(n, d uint64) uint {
+	return uint((2*n + d) / (2 * d))
+}
@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	err_util "product-manager/utils/error"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookSubscription sends the events it lists to URL, each request signed
+// with Secret.
+type WebhookSubscription struct {
+	ID          uint          `gorm:"primaryKey;autoIncrement" json:"id"`
+	URL         string        `gorm:"type:varchar(2048);not null" json:"url"`
+	Secret      string        `gorm:"type:varchar(255);not null" json:"-"`
+	Events      WebhookEvents `gorm:"type:jsonb;not null" json:"events"`
+	Description string        `gorm:"type:varchar(255);not null;default:''" json:"description"`
+	Active      bool          `gorm:"not null;default:true" json:"active"`
+	CreatedAt   time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (s *WebhookSubscription) IsValid() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return err_util.ErrInvalidWebhookURL
+	}
+	if len(s.Events) == 0 {
+		return err_util.ErrInvalidWebhookEvent
+	}
+	for _, event := range s.Events {
+		if !IsEventName(event) {
+			return fmt.Errorf("%w: %s", err_util.ErrInvalidWebhookEvent, event)
+		}
+	}
+	return nil
+}
+
+// WebhookDelivery is one event on its way to one subscription. EventID is
+// shared by every delivery of the same event, redeliveries included, so
+// receivers can drop duplicates.
+type WebhookDelivery struct {
+	ID             uint                 `gorm:"primaryKey;autoIncrement" json:"id"`
+	SubscriptionID uint                 `gorm:"not null;index" json:"subscription_id"`
+	Subscription   *WebhookSubscription `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	EventID        uuid.UUID            `gorm:"type:uuid;not null;index" json:"event_id"`
+	Event          string               `gorm:"type:varchar(50);not null" json:"event"`
+	Payload        string               `gorm:"type:jsonb;not null" json:"payload"`
+	Status         string               `gorm:"type:varchar(20);not null;index:idx_webhook_deliveries_due,priority:1" json:"status"`
+	Attempts       int                  `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  *time.Time           `gorm:"index:idx_webhook_deliveries_due,priority:2" json:"next_attempt_at"`
+	LastStatusCode *int                 `json:"last_status_code"`
+	LastError      string               `gorm:"type:text;not null;default:''" json:"last_error"`
+	CreatedAt      time.Time            `gorm:"autoCreateTime;index" json:"created_at"`
+	UpdatedAt      time.Time            `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// WebhookAttempt records a single request made for a delivery.
+type WebhookAttempt struct {
+	ID           uint             `gorm:"primaryKey;autoIncrement" json:"id"`
+	DeliveryID   uint             `gorm:"not null;index" json:"delivery_id"`
+	Delivery     *WebhookDelivery `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Attempt      int              `gorm:"not null" json:"attempt"`
+	StatusCode   *int             `json:"status_code"`
+	ResponseBody string           `gorm:"type:text;not null;default:''" json:"response_body"`
+	Error        string           `gorm:"type:text;not null;default:''" json:"error"`
+	DurationMs   int64            `gorm:"not null" json:"duration_ms"`
+	CreatedAt    time.Time        `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type WebhookEvents []string
+
+func (e WebhookEvents) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (e *WebhookEvents) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*e = WebhookEvents{}
+		return nil
+	default:
+		return errors.New("unsupported type for WebhookEvents")
+	}
+	return json.Unmarshal(b, e)
+}
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
 	"log"
 	"product-manager/config"
 	"product-manager/drivers/databases"
+	"product-manager/drivers/storage"
+	"product-manager/entities"
 	"product-manager/routes"
+	"product-manager/utils/events"
 	"product-manager/utils/validation"
 
 	"github.com/labstack/echo/v4"
@@ -18,29 +22,52 @@ func main() {
 
 	db := databases.ConnectDB(dbConfig)
 
+	store, err := storage.NewStorage(config.InitConfigStorage())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	bus := events.NewBus()
+	bus.Subscribe(entities.EventStockLow, func(ctx context.Context, event events.Event) {
+		var level entities.StockLevel
+		if err := event.Decode(&level); err != nil || level.ReorderPoint == nil {
+			return
+		}
+		log.Printf("stock low: product %d %q has %d left, reorder point %d", level.ProductID, level.Name, level.Stock, *level.ReorderPoint)
+	})
+
 	v := validation.NewValidator()
 
 	e := echo.New()
 
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-	AllowOrigins: []string{
-		"http://localhost:5173",
-		"https://management-product-5qdg.vercel.app",
-	},
-	AllowHeaders: []string{
-		echo.HeaderOrigin,
-		echo.HeaderContentType,
-		echo.HeaderAccept,
-		echo.HeaderAuthorization,
-		echo.HeaderXCSRFToken,
-	},
-	AllowMethods: []string{
-		echo.GET, echo.HEAD, echo.PUT, echo.PATCH, echo.POST, echo.DELETE,
-	},
-	AllowCredentials: true,
-}))
-
-	routes.InitRoute(e, db, v)
+		AllowOrigins: []string{
+			"http://localhost:5173",
+			"https://management-product-5qdg.vercel.app",
+		},
+		AllowHeaders: []string{
+			echo.HeaderOrigin,
+			echo.HeaderContentType,
+			echo.HeaderAccept,
+			echo.HeaderAuthorization,
+			echo.HeaderXCSRFToken,
+			"If-Match",
+		},
+		ExposeHeaders: []string{
+			"ETag",
+		},
+		AllowMethods: []string{
+			echo.GET, echo.HEAD, echo.PUT, echo.PATCH, echo.POST, echo.DELETE,
+		},
+		AllowCredentials: true,
+	}))
+
+	// The local backend serves its files straight from disk
+	if local, ok := store.(*storage.LocalStorage); ok {
+		e.Static(local.PublicURL, local.Root)
+	}
+
+	routes.InitRoute(e, db, v, store, bus, config.InitConfigTax())
 
 	log.Fatal(e.Start(":8080"))
 }
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"product-manager/entities"
 
 	"github.com/google/uuid"
@@ -12,6 +14,7 @@ type AdminRepository interface {
 	Register(ctx context.Context, admin *entities.Admin) error
 	Login(ctx context.Context, admin *entities.Admin) (*entities.Admin, error)
 	FindByID(ctx context.Context, id uuid.UUID, admin *entities.Admin) error
+	GetPermissions(ctx context.Context, id uuid.UUID) ([]string, error)
 }
 
 type adminRepository struct {
@@ -45,3 +48,17 @@ func (r *adminRepository) Login(ctx context.Context, admin *entities.Admin) (*en
 func (r *adminRepository) FindByID(ctx context.Context, id uuid.UUID, admin *entities.Admin) error {
 	return r.db.WithContext(ctx).First(admin, "id = ?", id).Error
 }
+
+// GetPermissions returns the permissions the admin holds now, or none if the
+// admin no longer exists.
+func (r *adminRepository) GetPermissions(ctx context.Context, id uuid.UUID) ([]string, error) {
+	var admin entities.Admin
+	err := r.db.WithContext(ctx).Select("permissions").Take(&admin, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin permissions: %w", err)
+	}
+	return admin.Permissions, nil
+}
@@ -0,0 +1,325 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-manager/entities"
+
+	err_util "product-manager/utils/error"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category *entities.Category) error
+	GetByID(ctx context.Context, id uint) (*entities.Category, error)
+	GetByIDForUpdate(ctx context.Context, id uint) (*entities.Category, error)
+	GetBySlug(ctx context.Context, slug string) (*entities.Category, error)
+	GetAll(ctx context.Context) ([]entities.Category, error)
+	Update(ctx context.Context, category *entities.Category) error
+	Move(ctx context.Context, id uint, parentID *uint, position int) error
+	Delete(ctx context.Context, id uint) error
+	ExistsBySlug(ctx context.Context, slug string, excludeID ...uint) (bool, error)
+	CountProducts(ctx context.Context) (map[uint]int64, error)
+}
+
+// categoryTreeLockKey serializes writes to the tree shape, so two moves can
+// never each pass the cycle check and together form a loop, and no category
+// is created under a path that is being rewritten.
+const categoryTreeLockKey = 7_301_001
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &categoryRepository{
+		db: db,
+	}
+}
+
+func (r *categoryRepository) Create(ctx context.Context, category *entities.Category) error {
+	if err := category.IsValid(); err != nil {
+		return err
+	}
+
+	exists, err := r.ExistsBySlug(ctx, category.Slug)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return err_util.ErrCategoryAlreadyExists
+	}
+
+	return getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", categoryTreeLockKey).Error; err != nil {
+			return fmt.Errorf("failed to lock category tree: %w", err)
+		}
+
+		var parent *entities.Category
+		if category.ParentID != nil {
+			var err error
+			if parent, err = r.lockCategory(tx, *category.ParentID); err != nil {
+				return err
+			}
+		}
+
+		// New categories go to the end of their siblings
+		var count int64
+		if err := whereParent(tx.Model(&entities.Category{}), category.ParentID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count sibling categories: %w", err)
+		}
+		category.Position = int(count)
+
+		if err := tx.Create(category).Error; err != nil {
+			return fmt.Errorf("failed to create category: %w", err)
+		}
+
+		category.Path = entities.ChildPath(parent, category.ID)
+		if err := tx.Model(category).UpdateColumn("path", category.Path).Error; err != nil {
+			return fmt.Errorf("failed to set category path: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *categoryRepository) GetByID(ctx context.Context, id uint) (*entities.Category, error) {
+	if id == 0 {
+		return nil, err_util.ErrInvalidCategoryID
+	}
+
+	var category entities.Category
+	if err := getDB(ctx, r.db).First(&category, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get category by ID: %w", err)
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) GetByIDForUpdate(ctx context.Context, id uint) (*entities.Category, error) {
+	if id == 0 {
+		return nil, err_util.ErrInvalidCategoryID
+	}
+	return r.lockCategory(getDB(ctx, r.db), id)
+}
+
+func (r *categoryRepository) lockCategory(db *gorm.DB, id uint) (*entities.Category, error) {
+	var category entities.Category
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&category, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to lock category: %w", err)
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) GetBySlug(ctx context.Context, slug string) (*entities.Category, error) {
+	var category entities.Category
+	if err := getDB(ctx, r.db).Where("slug = ?", slug).First(&category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get category by slug: %w", err)
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) GetAll(ctx context.Context) ([]entities.Category, error) {
+	var categories []entities.Category
+	if err := getDB(ctx, r.db).Order("position ASC, name ASC").Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	return categories, nil
+}
+
+// Update also rewrites the category name cached on its products.
+func (r *categoryRepository) Update(ctx context.Context, category *entities.Category) error {
+	if err := category.IsValid(); err != nil {
+		return err
+	}
+
+	exists, err := r.ExistsBySlug(ctx, category.Slug, category.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return err_util.ErrCategoryAlreadyExists
+	}
+
+	return getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(category).Select("name", "slug", "reorder_point", "reorder_quantity").Updates(category)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update category: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return err_util.ErrCategoryNotFound
+		}
+
+		err := tx.Unscoped().
+			Model(&entities.Product{}).
+			Where("category_id = ?", category.ID).
+			UpdateColumn("category", category.Name).Error
+		if err != nil {
+			return fmt.Errorf("failed to sync product categories: %w", err)
+		}
+		return nil
+	})
+}
+
+// Move places a category under parentID, or at the root when parentID is nil,
+// at the given position among its new siblings; a position out of range
+// appends. Positions on both the old and the new sibling lists stay
+// contiguous, and every descendant path follows the moved subtree.
+func (r *categoryRepository) Move(ctx context.Context, id uint, parentID *uint, position int) error {
+	return getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", categoryTreeLockKey).Error; err != nil {
+			return fmt.Errorf("failed to lock category tree: %w", err)
+		}
+
+		category, err := r.lockCategory(tx, id)
+		if err != nil {
+			return err
+		}
+
+		var parent *entities.Category
+		if parentID != nil {
+			if parent, err = r.lockCategory(tx, *parentID); err != nil {
+				return err
+			}
+			if parent.IsDescendantOf(category) {
+				return err_util.ErrCategoryCycle
+			}
+		}
+
+		// Close the gap left behind
+		err = whereParent(tx.Model(&entities.Category{}), category.ParentID).
+			Where("position > ?", category.Position).
+			UpdateColumn("position", gorm.Expr("position - 1")).Error
+		if err != nil {
+			return fmt.Errorf("failed to reorder sibling categories: %w", err)
+		}
+
+		var count int64
+		err = whereParent(tx.Model(&entities.Category{}), parentID).
+			Where("id <> ?", category.ID).
+			Count(&count).Error
+		if err != nil {
+			return fmt.Errorf("failed to count sibling categories: %w", err)
+		}
+		if position < 0 || position > int(count) {
+			position = int(count)
+		}
+
+		// Open a gap at the destination
+		err = whereParent(tx.Model(&entities.Category{}), parentID).
+			Where("position >= ? AND id <> ?", position, category.ID).
+			UpdateColumn("position", gorm.Expr("position + 1")).Error
+		if err != nil {
+			return fmt.Errorf("failed to reorder sibling categories: %w", err)
+		}
+
+		oldPath := category.Path
+		category.ParentID = parentID
+		category.Position = position
+		category.Path = entities.ChildPath(parent, category.ID)
+
+		if err := tx.Model(category).Select("parent_id", "position", "path").Updates(category).Error; err != nil {
+			return fmt.Errorf("failed to move category: %w", err)
+		}
+
+		if oldPath != category.Path {
+			err = tx.Model(&entities.Category{}).
+				Where("path LIKE ? AND id <> ?", oldPath+"%", category.ID).
+				UpdateColumn("path", gorm.Expr("? || SUBSTRING(path FROM ?)", category.Path, len(oldPath)+1)).Error
+			if err != nil {
+				return fmt.Errorf("failed to move category descendants: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *categoryRepository) Delete(ctx context.Context, id uint) error {
+	return getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		category, err := r.lockCategory(tx, id)
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&entities.Category{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count subcategories: %w", err)
+		}
+		if count > 0 {
+			return err_util.ErrCategoryHasChildren
+		}
+
+		if err := tx.Unscoped().Model(&entities.Product{}).Where("category_id = ?", id).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count category products: %w", err)
+		}
+		if count > 0 {
+			return err_util.ErrCategoryInUse
+		}
+
+		if err := tx.Delete(category).Error; err != nil {
+			return fmt.Errorf("failed to delete category: %w", err)
+		}
+
+		err = whereParent(tx.Model(&entities.Category{}), category.ParentID).
+			Where("position > ?", category.Position).
+			UpdateColumn("position", gorm.Expr("position - 1")).Error
+		if err != nil {
+			return fmt.Errorf("failed to reorder sibling categories: %w", err)
+		}
+		return nil
+	})
+}
+
+// CountProducts returns the number of live products assigned directly to
+// each category.
+func (r *categoryRepository) CountProducts(ctx context.Context) (map[uint]int64, error) {
+	var rows []struct {
+		CategoryID uint
+		Count      int64
+	}
+	err := getDB(ctx, r.db).
+		Model(&entities.Product{}).
+		Select("category_id, COUNT(*) AS count").
+		Where("category_id IS NOT NULL").
+		Group("category_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count category products: %w", err)
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *categoryRepository) ExistsBySlug(ctx context.Context, slug string, excludeID ...uint) (bool, error) {
+	query := getDB(ctx, r.db).Model(&entities.Category{}).Where("slug = ?", slug)
+	if len(excludeID) > 0 && excludeID[0] > 0 {
+		query = query.Where("id != ?", excludeID[0])
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check category slug existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+func whereParent(query *gorm.DB, parentID *uint) *gorm.DB {
+	if parentID == nil {
+		return query.Where("parent_id IS NULL")
+	}
+	return query.Where("parent_id = ?", *parentID)
+}
@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-manager/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CostLayerRepository interface {
+	Create(ctx context.Context, layer *entities.CostLayer) error
+	GetOpen(ctx context.Context, productID uint, variantID *uint) ([]entities.CostLayer, error)
+	Consume(ctx context.Context, consumptions []entities.CostLayerConsumption) error
+	GetReceivedBefore(ctx context.Context, at time.Time) ([]entities.CostLayer, error)
+	GetConsumedBefore(ctx context.Context, at time.Time) ([]entities.CostLayerConsumption, error)
+	GetUnlayeredProducts(ctx context.Context) ([]entities.Product, error)
+	LockIfUnlayered(ctx context.Context, productID uint) (bool, error)
+}
+
+type costLayerRepository struct {
+	db *gorm.DB
+}
+
+func NewCostLayerRepository(db *gorm.DB) CostLayerRepository {
+	return &costLayerRepository{
+		db: db,
+	}
+}
+
+func (r *costLayerRepository) Create(ctx context.Context, layer *entities.CostLayer) error {
+	if err := getDB(ctx, r.db).Create(layer).Error; err != nil {
+		return fmt.Errorf("failed to create cost layer: %w", err)
+	}
+	return nil
+}
+
+// GetOpen returns the layers with stock left of a variant, or of the product
+// itself when variantID is nil, oldest first.
+func (r *costLayerRepository) GetOpen(ctx context.Context, productID uint, variantID *uint) ([]entities.CostLayer, error) {
+	var layers []entities.CostLayer
+	query := getDB(ctx, r.db).Where("product_id = ? AND remaining > 0", productID)
+	if variantID != nil {
+		query = query.Where("variant_id = ?", *variantID)
+	} else {
+		query = query.Where("variant_id IS NULL")
+	}
+	err := query.
+		Order("received_at ASC, id ASC").
+		Find(&layers).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open cost layers: %w", err)
+	}
+	return layers, nil
+}
+
+// Consume records consumptions and takes them off the layers they came
+// from.
+func (r *costLayerRepository) Consume(ctx context.Context, consumptions []entities.CostLayerConsumption) error {
+	if len(consumptions) == 0 {
+		return nil
+	}
+
+	return getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, c := range consumptions {
+			err := tx.Model(&entities.CostLayer{}).
+				Where("id = ?", c.LayerID).
+				UpdateColumn("remaining", gorm.Expr("remaining - ?", c.Quantity)).Error
+			if err != nil {
+				return fmt.Errorf("failed to consume cost layer: %w", err)
+			}
+		}
+		if err := tx.Create(&consumptions).Error; err != nil {
+			return fmt.Errorf("failed to create cost layer consumptions: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetReceivedBefore returns every layer received before at with its
+// product, trashed ones included since their stock is still on hand.
+func (r *costLayerRepository) GetReceivedBefore(ctx context.Context, at time.Time) ([]entities.CostLayer, error) {
+	var layers []entities.CostLayer
+	err := getDB(ctx, r.db).
+		Preload("Product", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("received_at < ?", at).
+		Order("product_id ASC, received_at ASC, id ASC").
+		Find(&layers).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cost layers: %w", err)
+	}
+	return layers, nil
+}
+
+func (r *costLayerRepository) GetConsumedBefore(ctx context.Context, at time.Time) ([]entities.CostLayerConsumption, error) {
+	var consumptions []entities.CostLayerConsumption
+	err := getDB(ctx, r.db).
+		Where("consumed_at < ?", at).
+		Order("consumed_at ASC, id ASC").
+		Find(&consumptions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cost layer consumptions: %w", err)
+	}
+	return consumptions, nil
+}
+
+// GetUnlayeredProducts returns the products, trashed ones included, that
+// have stock movements but no cost layers yet: those whose history
+// predates cost layers.
+func (r *costLayerRepository) GetUnlayeredProducts(ctx context.Context) ([]entities.Product, error) {
+	var products []entities.Product
+	err := getDB(ctx, r.db).
+		Unscoped().
+		Where("EXISTS (SELECT 1 FROM stock_movements m WHERE m.product_id = products.id)").
+		Where("NOT EXISTS (SELECT 1 FROM cost_layers l WHERE l.product_id = products.id)").
+		Order("id ASC").
+		Find(&products).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products without cost layers: %w", err)
+	}
+	return products, nil
+}
+
+// LockIfUnlayered locks a product row, trashed ones included, for the rest
+// of the transaction and reports whether the product still has no cost
+// layers. Movements lock the same row, so none can slip in between.
+func (r *costLayerRepository) LockIfUnlayered(ctx context.Context, productID uint) (bool, error) {
+	var product entities.Product
+	err := getDB(ctx, r.db).
+		Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&product, productID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to lock product: %w", err)
+	}
+
+	var count int64
+	if err := getDB(ctx, r.db).Model(&entities.CostLayer{}).Where("product_id = ?", productID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to count cost layers: %w", err)
+	}
+	return count == 0, nil
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-manager/entities"
+
+	err_util "product-manager/utils/error"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ExchangeRateRepository interface {
+	GetAll(ctx context.Context) ([]entities.ExchangeRate, error)
+	Get(ctx context.Context, base, quote string) (*entities.ExchangeRate, error)
+	Save(ctx context.Context, rate *entities.ExchangeRate) error
+	Delete(ctx context.Context, base, quote string) error
+}
+
+type exchangeRateRepository struct {
+	db *gorm.DB
+}
+
+func NewExchangeRateRepository(db *gorm.DB) ExchangeRateRepository {
+	return &exchangeRateRepository{
+		db: db,
+	}
+}
+
+func (r *exchangeRateRepository) GetAll(ctx context.Context) ([]entities.ExchangeRate, error) {
+	var rates []entities.ExchangeRate
+	if err := getDB(ctx, r.db).Order("base ASC, quote ASC").Find(&rates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+	return rates, nil
+}
+
+func (r *exchangeRateRepository) Get(ctx context.Context, base, quote string) (*entities.ExchangeRate, error) {
+	var rate entities.ExchangeRate
+	err := getDB(ctx, r.db).Where("base = ? AND quote = ?", base, quote).First(&rate).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s/%s", err_util.ErrExchangeRateNotFound, base, quote)
+		}
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+	return &rate, nil
+}
+
+// Save creates the rate for its currency pair or replaces the one there is.
+func (r *exchangeRateRepository) Save(ctx context.Context, rate *entities.ExchangeRate) error {
+	err := getDB(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "base"}, {Name: "quote"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+		}).
+		Create(rate).Error
+	if err != nil {
+		return fmt.Errorf("failed to save exchange rate: %w", err)
+	}
+	return nil
+}
+
+func (r *exchangeRateRepository) Delete(ctx context.Context, base, quote string) error {
+	result := getDB(ctx, r.db).Where("base = ? AND quote = ?", base, quote).Delete(&entities.ExchangeRate{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete exchange rate: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s/%s", err_util.ErrExchangeRateNotFound, base, quote)
+	}
+	return nil
+}
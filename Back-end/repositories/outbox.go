@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"product-manager/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// outboxLockKey names the advisory lock held by whichever instance is
+// dispatching the outbox; it spells "outbox" in ASCII.
+const outboxLockKey = 0x6f7574626f78
+
+type OutboxRepository interface {
+	Add(ctx context.Context, event *entities.OutboxEvent) error
+	LockDispatch(ctx context.Context) (bool, error)
+	GetPending(ctx context.Context, limit int) ([]entities.OutboxEvent, error)
+	MarkDispatched(ctx context.Context, ids []uint64) error
+	RecordFailure(ctx context.Context, event *entities.OutboxEvent) error
+	DeleteDispatched(ctx context.Context, before time.Time) (int64, error)
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{
+		db: db,
+	}
+}
+
+// Add writes event through the transaction carried by ctx, so it is only
+// ever seen if the change it describes commits.
+func (r *outboxRepository) Add(ctx context.Context, event *entities.OutboxEvent) error {
+	if err := getDB(ctx, r.db).Create(event).Error; err != nil {
+		return fmt.Errorf("failed to add outbox event: %w", err)
+	}
+	return nil
+}
+
+// LockDispatch takes the dispatch lock for the rest of the transaction
+// carried by ctx, and reports false when another instance holds it. Only
+// one dispatcher at a time keeps events in order.
+func (r *outboxRepository) LockDispatch(ctx context.Context) (bool, error) {
+	var locked bool
+	if err := getDB(ctx, r.db).Raw("SELECT pg_try_advisory_xact_lock(?)", outboxLockKey).Scan(&locked).Error; err != nil {
+		return false, fmt.Errorf("failed to lock outbox: %w", err)
+	}
+	return locked, nil
+}
+
+// GetPending returns up to limit events that are neither dispatched nor
+// given up on, oldest first.
+func (r *outboxRepository) GetPending(ctx context.Context, limit int) ([]entities.OutboxEvent, error) {
+	var events []entities.OutboxEvent
+	err := getDB(ctx, r.db).
+		Where("dispatched_at IS NULL AND failed_at IS NULL").
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending outbox events: %w", err)
+	}
+	return events, nil
+}
+
+func (r *outboxRepository) MarkDispatched(ctx context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := getDB(ctx, r.db).Model(&entities.OutboxEvent{}).
+		Where("id IN ?", ids).
+		UpdateColumn("dispatched_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox events dispatched: %w", err)
+	}
+	return nil
+}
+
+// RecordFailure saves the attempt count and error of an event a sink
+// turned down, and whether it has been given up on.
+func (r *outboxRepository) RecordFailure(ctx context.Context, event *entities.OutboxEvent) error {
+	err := getDB(ctx, r.db).Model(event).
+		Select("attempts", "last_error", "failed_at").
+		Updates(event).Error
+	if err != nil {
+		return fmt.Errorf("failed to record outbox failure: %w", err)
+	}
+	return nil
+}
+
+// DeleteDispatched removes events dispatched before the given time. Events
+// given up on are kept for inspection.
+func (r *outboxRepository) DeleteDispatched(ctx context.Context, before time.Time) (int64, error) {
+	res := getDB(ctx, r.db).
+		Where("dispatched_at < ?", before).
+		Delete(&entities.OutboxEvent{})
+	if res.Error != nil {
+		return 0, fmt.Errorf("failed to delete dispatched outbox events: %w", res.Error)
+	}
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"product-manager/entities"
+
+	dto_base "product-manager/dto/base"
+	dto "product-manager/dto/products"
+
+	"gorm.io/gorm"
+)
+
+type ProductAuditRepository interface {
+	Create(ctx context.Context, audit *entities.ProductAudit) error
+	GetByProductID(ctx context.Context, productID uint, pagination *dto_base.PaginationRequest) ([]entities.ProductAudit, int64, error)
+	GetAll(ctx context.Context, pagination *dto_base.PaginationRequest, filter *dto.ProductAuditFilter) ([]entities.ProductAudit, int64, error)
+}
+
+type productAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewProductAuditRepository(db *gorm.DB) ProductAuditRepository {
+	return &productAuditRepository{
+		db: db,
+	}
+}
+
+func (r *productAuditRepository) Create(ctx context.Context, audit *entities.ProductAudit) error {
+	if err := getDB(ctx, r.db).Create(audit).Error; err != nil {
+		return fmt.Errorf("failed to create product audit: %w", err)
+	}
+	return nil
+}
+
+func (r *productAuditRepository) GetByProductID(ctx context.Context, productID uint, pagination *dto_base.PaginationRequest) ([]entities.ProductAudit, int64, error) {
+	query := getDB(ctx, r.db).Model(&entities.ProductAudit{}).Where("product_id = ?", productID)
+	return r.paginate(query, pagination)
+}
+
+func (r *productAuditRepository) GetAll(ctx context.Context, pagination *dto_base.PaginationRequest, filter *dto.ProductAuditFilter) ([]entities.ProductAudit, int64, error) {
+	query := getDB(ctx, r.db).Model(&entities.ProductAudit{})
+
+	if filter != nil {
+		if filter.AdminID != nil {
+			query = query.Where("admin_id = ?", *filter.AdminID)
+		}
+		if filter.Action != "" {
+			query = query.Where("action = ?", filter.Action)
+		}
+		if filter.From != nil {
+			query = query.Where("created_at >= ?", *filter.From)
+		}
+		if filter.To != nil {
+			query = query.Where("created_at < ?", *filter.To)
+		}
+	}
+
+	return r.paginate(query, pagination)
+}
+
+func (r *productAuditRepository) paginate(query *gorm.DB, pagination *dto_base.PaginationRequest) ([]entities.ProductAudit, int64, error) {
+	var audits []entities.ProductAudit
+	var totalCount int64
+
+	if err := query.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count product audits: %w", err)
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(pagination.Limit).
+		Offset(offset).
+		Find(&audits).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get product audits: %w", err)
+	}
+
+	return audits, totalCount, nil
+}
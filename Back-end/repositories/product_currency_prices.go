@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"product-manager/entities"
+
+	err_util "product-manager/utils/error"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProductCurrencyPriceRepository interface {
+	GetByProductIDs(ctx context.Context, productIDs []uint, currency string) ([]entities.ProductCurrencyPrice, error)
+	Save(ctx context.Context, price *entities.ProductCurrencyPrice) error
+	Delete(ctx context.Context, productID uint, currency string) error
+}
+
+type productCurrencyPriceRepository struct {
+	db *gorm.DB
+}
+
+func NewProductCurrencyPriceRepository(db *gorm.DB) ProductCurrencyPriceRepository {
+	return &productCurrencyPriceRepository{
+		db: db,
+	}
+}
+
+// GetByProductIDs returns the prices of the given products, only those in
+// currency unless it is empty.
+func (r *productCurrencyPriceRepository) GetByProductIDs(ctx context.Context, productIDs []uint, currency string) ([]entities.ProductCurrencyPrice, error) {
+	var prices []entities.ProductCurrencyPrice
+	if len(productIDs) == 0 {
+		return prices, nil
+	}
+
+	query := getDB(ctx, r.db).Where("product_id IN ?", productIDs)
+	if currency != "" {
+		query = query.Where("price_currency = ?", currency)
+	}
+	if err := query.Order("product_id ASC, price_currency ASC").Find(&prices).Error; err != nil {
+		return nil, fmt.Errorf("failed to get currency prices: %w", err)
+	}
+	return prices, nil
+}
+
+// Save creates the product's price in its currency or replaces the one
+// there is.
+func (r *productCurrencyPriceRepository) Save(ctx context.Context, price *entities.ProductCurrencyPrice) error {
+	err := getDB(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "product_id"}, {Name: "price_currency"}},
+			DoUpdates: clause.AssignmentColumns([]string{"price_amount", "updated_at"}),
+		}).
+		Create(price).Error
+	if err != nil {
+		return fmt.Errorf("failed to save currency price: %w", err)
+	}
+	return nil
+}
+
+func (r *productCurrencyPriceRepository) Delete(ctx context.Context, productID uint, currency string) error {
+	result := getDB(ctx, r.db).
+		Where("product_id = ? AND price_currency = ?", productID, currency).
+		Delete(&entities.ProductCurrencyPrice{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete currency price: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return err_util.ErrCurrencyPriceNotFound
+	}
+	return nil
+}
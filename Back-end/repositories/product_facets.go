@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"product-manager/entities"
+
+	dto "product-manager/dto/products"
+)
+
+func (r *productRepository) CountByCategory(ctx context.Context, filter *dto.ProductSearchFilter) ([]entities.CategoryCount, error) {
+	db := getDB(ctx, r.db)
+	counts := r.applyFilters(db.Model(&entities.Product{}), filter).
+		Select("category_id, COUNT(*) AS count").
+		Where("category_id IS NOT NULL").
+		Group("category_id")
+
+	var rows []entities.CategoryCount
+	err := db.Table("(?) AS f", counts).
+		Select("c.id AS category_id, c.name, c.slug, f.count").
+		Joins("JOIN categories c ON c.id = f.category_id").
+		Order("f.count DESC, c.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count products by category: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *productRepository) GetPriceRange(ctx context.Context, filter *dto.ProductSearchFilter) (*entities.PriceRange, error) {
+	var priceRange entities.PriceRange
+	err := r.applyFilters(getDB(ctx, r.db).Model(&entities.Product{}), filter).
+		Select("COALESCE(MIN(price), 0) AS min, COALESCE(MAX(price), 0) AS max, COUNT(*) AS count").
+		Scan(&priceRange).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price range: %w", err)
+	}
+	return &priceRange, nil
+}
+
+func (r *productRepository) CountByPriceBucket(ctx context.Context, filter *dto.ProductSearchFilter, width uint) ([]entities.PriceBucketCount, error) {
+	var rows []entities.PriceBucketCount
+	err := r.applyFilters(getDB(ctx, r.db).Model(&entities.Product{}), filter).
+		Select("price / ? AS bucket, COUNT(*) AS count", width).
+		Group("bucket").
+		Order("bucket ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count products by price: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *productRepository) CountByStock(ctx context.Context, filter *dto.ProductSearchFilter) (*entities.StockCount, error) {
+	var counts entities.StockCount
+	err := r.applyFilters(getDB(ctx, r.db).Model(&entities.Product{}), filter).
+		Select(
+			"COUNT(*) FILTER (WHERE " + productTotalStockSQL + " > 0) AS in_stock, " +
+				"COUNT(*) FILTER (WHERE " + productTotalStockSQL + " = 0) AS out_of_stock",
+		).
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count products by stock: %w", err)
+	}
+	return &counts, nil
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	dto "product-manager/dto/products"
+	"product-manager/entities"
+	err_util "product-manager/utils/error"
+
+	"gorm.io/gorm"
+)
+
+var (
+	numericFilterOps = []string{dto.FilterEq, dto.FilterNe, dto.FilterLt, dto.FilterLte, dto.FilterGt, dto.FilterGte, dto.FilterIn, dto.FilterBetween}
+	timeFilterOps    = []string{dto.FilterEq, dto.FilterNe, dto.FilterLt, dto.FilterLte, dto.FilterGt, dto.FilterGte, dto.FilterBetween}
+	textFilterOps    = []string{dto.FilterEq, dto.FilterNe, dto.FilterIn, dto.FilterContains}
+)
+
+// filterField is a column that filter[field][op] may reach, with the
+// operators it accepts.
+type filterField struct {
+	expr string
+	kind valueKind
+	ops  []string
+}
+
+var productFilterFields = map[string]filterField{
+	"id":          {expr: "products.id", kind: valueInt, ops: numericFilterOps},
+	"name":        {expr: "name", kind: valueString, ops: textFilterOps},
+	"category":    {expr: "(SELECT c.slug FROM categories c WHERE c.id = products.category_id)", kind: valueString, ops: []string{dto.FilterEq, dto.FilterNe, dto.FilterIn}},
+	"category_id": {expr: "category_id", kind: valueInt, ops: []string{dto.FilterEq, dto.FilterNe, dto.FilterIn}},
+	"price":       {expr: "price", kind: valueInt, ops: numericFilterOps},
+	"stock":       {expr: "products.stock", kind: valueInt, ops: numericFilterOps},
+	"total_stock": {expr: productTotalStockSQL, kind: valueInt, ops: numericFilterOps},
+	"created_at":  {expr: "created_at", kind: valueTime, ops: timeFilterOps},
+	"updated_at":  {expr: "updated_at", kind: valueTime, ops: timeFilterOps},
+}
+
+// validateConditions checks the filter conditions up front so callers get
+// the error naming the bad key rather than a failed query.
+func validateConditions(filter *dto.ProductSearchFilter) error {
+	if filter == nil {
+		return nil
+	}
+	for _, condition := range filter.Conditions {
+		if _, _, err := filterClause(condition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyConditions(query *gorm.DB, conditions []dto.FilterCondition) *gorm.DB {
+	for _, condition := range conditions {
+		clause, args, err := filterClause(condition)
+		if err != nil {
+			query.AddError(err)
+			return query
+		}
+		query = query.Where(clause, args...)
+	}
+	return query
+}
+
+// filterClause turns a condition into a WHERE clause over an allow-listed
+// field, with its values parsed to the field's type.
+func filterClause(condition dto.FilterCondition) (string, []any, error) {
+	field, ok := productFilterFields[condition.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %s", err_util.ErrInvalidFilterField, condition.Key())
+	}
+	if !slices.Contains(field.ops, condition.Op) {
+		return "", nil, fmt.Errorf("%w: %s", err_util.ErrInvalidFilterOp, condition.Key())
+	}
+
+	arity := 1
+	switch condition.Op {
+	case dto.FilterIn:
+		arity = len(condition.Values)
+	case dto.FilterBetween:
+		arity = 2
+	}
+	if arity == 0 || len(condition.Values) != arity {
+		return "", nil, fmt.Errorf("%w: %s", err_util.ErrInvalidFilterValue, condition.Key())
+	}
+
+	values := make([]any, len(condition.Values))
+	for i, raw := range condition.Values {
+		value, err := parseFilterValue(field.kind, raw)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %s", err_util.ErrInvalidFilterValue, condition.Key())
+		}
+		if condition.Field == "category" {
+			value = entities.Slugify(raw)
+		}
+		values[i] = value
+	}
+
+	switch condition.Op {
+	case dto.FilterEq:
+		return field.expr + " = ?", values, nil
+	case dto.FilterNe:
+		return field.expr + " IS DISTINCT FROM ?", values, nil
+	case dto.FilterLt:
+		return field.expr + " < ?", values, nil
+	case dto.FilterLte:
+		return field.expr + " <= ?", values, nil
+	case dto.FilterGt:
+		return field.expr + " > ?", values, nil
+	case dto.FilterGte:
+		return field.expr + " >= ?", values, nil
+	case dto.FilterIn:
+		return field.expr + " IN ?", []any{values}, nil
+	case dto.FilterContains:
+		return field.expr + " ILIKE ?", []any{"%" + escapeLike(condition.Values[0]) + "%"}, nil
+	default:
+		return field.expr + " BETWEEN ? AND ?", values, nil
+	}
+}
+
+func parseFilterValue(kind valueKind, raw string) (any, error) {
+	switch kind {
+	case valueInt:
+		return strconv.ParseUint(raw, 10, 64)
+	case valueTime:
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t, nil
+		}
+		return time.Parse(time.DateOnly, raw)
+	default:
+		return raw, nil
+	}
+}
+
+// escapeLike makes LIKE wildcards in s match literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-manager/entities"
+
+	err_util "product-manager/utils/error"
+
+	"gorm.io/gorm"
+)
+
+type ProductImageRepository interface {
+	Create(ctx context.Context, image *entities.ProductImage, position int) error
+	GetByID(ctx context.Context, productID, id uint) (*entities.ProductImage, error)
+	GetByProductIDs(ctx context.Context, productIDs []uint) ([]entities.ProductImage, error)
+	Move(ctx context.Context, image *entities.ProductImage, position int) error
+	SetPrimary(ctx context.Context, productID, id uint) error
+	Delete(ctx context.Context, image *entities.ProductImage) error
+}
+
+type productImageRepository struct {
+	db *gorm.DB
+}
+
+func NewProductImageRepository(db *gorm.DB) ProductImageRepository {
+	return &productImageRepository{
+		db: db,
+	}
+}
+
+// Create inserts image at position among the product's images, appending
+// when position is out of range. The first image of a product is always
+// primary. Callers must hold the product row lock.
+func (r *productImageRepository) Create(ctx context.Context, image *entities.ProductImage, position int) error {
+	return getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entities.ProductImage{}).Where("product_id = ?", image.ProductID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count product images: %w", err)
+		}
+		if position < 0 || position > int(count) {
+			position = int(count)
+		}
+		image.Position = position
+		if count == 0 {
+			image.IsPrimary = true
+		}
+
+		err := tx.Model(&entities.ProductImage{}).
+			Where("product_id = ? AND position >= ?", image.ProductID, position).
+			UpdateColumn("position", gorm.Expr("position + 1")).Error
+		if err != nil {
+			return fmt.Errorf("failed to reorder product images: %w", err)
+		}
+
+		if image.IsPrimary {
+			if err := clearPrimaryImage(tx, image.ProductID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(image).Error; err != nil {
+			return fmt.Errorf("failed to create product image: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *productImageRepository) GetByID(ctx context.Context, productID, id uint) (*entities.ProductImage, error) {
+	if id == 0 {
+		return nil, err_util.ErrInvalidImageID
+	}
+
+	var image entities.ProductImage
+	err := getDB(ctx, r.db).Where("product_id = ?", productID).First(&image, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to get product image by ID: %w", err)
+	}
+	return &image, nil
+}
+
+func (r *productImageRepository) GetByProductIDs(ctx context.Context, productIDs []uint) ([]entities.ProductImage, error) {
+	var images []entities.ProductImage
+	if len(productIDs) == 0 {
+		return images, nil
+	}
+
+	err := getDB(ctx, r.db).
+		Where("product_id IN ?", productIDs).
+		Order("product_id ASC, position ASC").
+		Find(&images).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product images: %w", err)
+	}
+	return images, nil
+}
+
+// Move shifts image to position, keeping the product's positions
+// contiguous. Callers must hold the product row lock.
+func (r *productImageRepository) Move(ctx context.Context, image *entities.ProductImage, position int) error {
+	return getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entities.ProductImage{}).Where("product_id = ?", image.ProductID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count product images: %w", err)
+		}
+		if position < 0 || position >= int(count) {
+			position = int(count) - 1
+		}
+		if position == image.Position {
+			return nil
+		}
+
+		shift := tx.Model(&entities.ProductImage{}).Where("product_id = ? AND id <> ?", image.ProductID, image.ID)
+		var err error
+		if position < image.Position {
+			err = shift.Where("position >= ? AND position < ?", position, image.Position).
+				UpdateColumn("position", gorm.Expr("position + 1")).Error
+		} else {
+			err = shift.Where("position > ? AND position <= ?", image.Position, position).
+				UpdateColumn("position", gorm.Expr("position - 1")).Error
+		}
+		if err != nil {
+			return fmt.Errorf("failed to reorder product images: %w", err)
+		}
+
+		image.Position = position
+		if err := tx.Model(image).UpdateColumn("position", position).Error; err != nil {
+			return fmt.Errorf("failed to move product image: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *productImageRepository) SetPrimary(ctx context.Context, productID, id uint) error {
+	return getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := clearPrimaryImage(tx, productID); err != nil {
+			return err
+		}
+
+		result := tx.Model(&entities.ProductImage{}).
+			Where("product_id = ? AND id = ?", productID, id).
+			UpdateColumn("is_primary", true)
+		if result.Error != nil {
+			return fmt.Errorf("failed to set primary image: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return err_util.ErrImageNotFound
+		}
+		return nil
+	})
+}
+
+// Delete removes image, closes the gap it leaves and, when it was primary,
+// promotes the image now first in line. Callers must hold the product row
+// lock.
+func (r *productImageRepository) Delete(ctx context.Context, image *entities.ProductImage) error {
+	return getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(image)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete product image: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return err_util.ErrImageNotFound
+		}
+
+		err := tx.Model(&entities.ProductImage{}).
+			Where("product_id = ? AND position > ?", image.ProductID, image.Position).
+			UpdateColumn("position", gorm.Expr("position - 1")).Error
+		if err != nil {
+			return fmt.Errorf("failed to reorder product images: %w", err)
+		}
+
+		if image.IsPrimary {
+			err = tx.Model(&entities.ProductImage{}).
+				Where("product_id = ? AND position = 0", image.ProductID).
+				UpdateColumn("is_primary", true).Error
+			if err != nil {
+				return fmt.Errorf("failed to promote primary image: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func clearPrimaryImage(tx *gorm.DB, productID uint) error {
+	err := tx.Model(&entities.ProductImage{}).
+		Where("product_id = ? AND is_primary", productID).
+		UpdateColumn("is_primary", false).Error
+	if err != nil {
+		return fmt.Errorf("failed to clear primary image: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-manager/entities"
+	"time"
+
+	err_util "product-manager/utils/error"
+
+	"gorm.io/gorm"
+)
+
+type ProductPriceRepository interface {
+	Create(ctx context.Context, price *entities.ProductPrice) error
+	GetByID(ctx context.Context, productID, id uint) (*entities.ProductPrice, error)
+	GetByProductID(ctx context.Context, productID uint) ([]entities.ProductPrice, error)
+	GetAt(ctx context.Context, productID uint, at time.Time) (*entities.ProductPrice, error)
+	GetOverlapping(ctx context.Context, productID uint, from time.Time, to *time.Time) ([]entities.ProductPrice, error)
+	GetNextStart(ctx context.Context, productID uint, after time.Time) (*time.Time, error)
+	Update(ctx context.Context, price *entities.ProductPrice) error
+	Delete(ctx context.Context, id uint) error
+	GetDueProductIDs(ctx context.Context, now time.Time, limit int) ([]uint, error)
+	MarkApplied(ctx context.Context, productID uint, now time.Time) error
+	NextDueAt(ctx context.Context) (*time.Time, error)
+}
+
+type productPriceRepository struct {
+	db *gorm.DB
+}
+
+func NewProductPriceRepository(db *gorm.DB) ProductPriceRepository {
+	return &productPriceRepository{
+		db: db,
+	}
+}
+
+func (r *productPriceRepository) Create(ctx context.Context, price *entities.ProductPrice) error {
+	if err := getDB(ctx, r.db).Create(price).Error; err != nil {
+		return fmt.Errorf("failed to create price: %w", err)
+	}
+	return nil
+}
+
+func (r *productPriceRepository) GetByID(ctx context.Context, productID, id uint) (*entities.ProductPrice, error) {
+	if id == 0 {
+		return nil, err_util.ErrInvalidPriceID
+	}
+
+	var price entities.ProductPrice
+	err := getDB(ctx, r.db).Where("product_id = ?", productID).First(&price, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrPriceNotFound
+		}
+		return nil, fmt.Errorf("failed to get price by ID: %w", err)
+	}
+	return &price, nil
+}
+
+// GetByProductID returns the whole timeline of a product, oldest first.
+func (r *productPriceRepository) GetByProductID(ctx context.Context, productID uint) ([]entities.ProductPrice, error) {
+	var prices []entities.ProductPrice
+	err := getDB(ctx, r.db).
+		Where("product_id = ?", productID).
+		Order("effective_from ASC, id ASC").
+		Find(&prices).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prices: %w", err)
+	}
+	return prices, nil
+}
+
+// GetAt returns the price in effect at the given time.
+func (r *productPriceRepository) GetAt(ctx context.Context, productID uint, at time.Time) (*entities.ProductPrice, error) {
+	var price entities.ProductPrice
+	err := getDB(ctx, r.db).
+		Where("product_id = ? AND effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", productID, at, at).
+		Order("effective_from DESC").
+		First(&price).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrPriceNotFound
+		}
+		return nil, fmt.Errorf("failed to get price at %s: %w", at.Format(time.RFC3339), err)
+	}
+	return &price, nil
+}
+
+// GetOverlapping returns the prices whose period overlaps [from, to), oldest
+// first. A nil to leaves the range open-ended.
+func (r *productPriceRepository) GetOverlapping(ctx context.Context, productID uint, from time.Time, to *time.Time) ([]entities.ProductPrice, error) {
+	query := getDB(ctx, r.db).
+		Where("product_id = ? AND (effective_to IS NULL OR effective_to > ?)", productID, from)
+	if to != nil {
+		query = query.Where("effective_from < ?", *to)
+	}
+
+	var prices []entities.ProductPrice
+	if err := query.Order("effective_from ASC, id ASC").Find(&prices).Error; err != nil {
+		return nil, fmt.Errorf("failed to get prices: %w", err)
+	}
+	return prices, nil
+}
+
+// GetNextStart returns when the first price of a product starting after
+// after takes effect, or nil when none does.
+func (r *productPriceRepository) GetNextStart(ctx context.Context, productID uint, after time.Time) (*time.Time, error) {
+	var prices []entities.ProductPrice
+	err := getDB(ctx, r.db).
+		Where("product_id = ? AND effective_from > ?", productID, after).
+		Order("effective_from ASC").
+		Limit(1).
+		Find(&prices).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next price: %w", err)
+	}
+	if len(prices) == 0 {
+		return nil, nil
+	}
+	return &prices[0].EffectiveFrom, nil
+}
+
+// Update saves the period of price; the amount of a price never changes
+// once recorded.
+func (r *productPriceRepository) Update(ctx context.Context, price *entities.ProductPrice) error {
+	err := getDB(ctx, r.db).Model(price).
+		Select("effective_from", "effective_to").
+		Updates(price).Error
+	if err != nil {
+		return fmt.Errorf("failed to update price: %w", err)
+	}
+	return nil
+}
+
+func (r *productPriceRepository) Delete(ctx context.Context, id uint) error {
+	if err := getDB(ctx, r.db).Delete(&entities.ProductPrice{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete price: %w", err)
+	}
+	return nil
+}
+
+// GetDueProductIDs returns up to limit products with a price that has taken
+// effect but not been applied yet.
+func (r *productPriceRepository) GetDueProductIDs(ctx context.Context, now time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := getDB(ctx, r.db).
+		Model(&entities.ProductPrice{}).
+		Where("applied_at IS NULL AND effective_from <= ?", now).
+		Group("product_id").
+		Order("MIN(effective_from) ASC").
+		Limit(limit).
+		Pluck("product_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due prices: %w", err)
+	}
+	return ids, nil
+}
+
+// MarkApplied marks every price of a product that has taken effect by now
+// as applied.
+func (r *productPriceRepository) MarkApplied(ctx context.Context, productID uint, now time.Time) error {
+	err := getDB(ctx, r.db).
+		Model(&entities.ProductPrice{}).
+		Where("product_id = ? AND applied_at IS NULL AND effective_from <= ?", productID, now).
+		UpdateColumn("applied_at", now).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark prices applied: %w", err)
+	}
+	return nil
+}
+
+// NextDueAt returns when the earliest unapplied price takes effect, or nil
+// when nothing is waiting.
+func (r *productPriceRepository) NextDueAt(ctx context.Context) (*time.Time, error) {
+	var next *time.Time
+	err := getDB(ctx, r.db).
+		Model(&entities.ProductPrice{}).
+		Where("applied_at IS NULL").
+		Select("MIN(effective_from)").
+		Scan(&next).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next due price: %w", err)
+	}
+	return next, nil
+}
@@ -0,0 +1,270 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	dto "product-manager/dto/products"
+	"product-manager/entities"
+	err_util "product-manager/utils/error"
+
+	"gorm.io/gorm"
+)
+
+type valueKind int
+
+const (
+	valueString valueKind = iota
+	valueInt
+	valueFloat
+	valueTime
+)
+
+// sortKey is one term of a product ordering. column is what ORDER BY uses,
+// an alias for computed values; expr and args repeat the value for WHERE
+// clauses, where aliases are not visible.
+type sortKey struct {
+	field  string
+	column string
+	expr   string
+	args   []any
+	kind   valueKind
+	desc   bool
+	value  func(p *entities.Product) any
+}
+
+func productSortField(field string, filter *dto.ProductSearchFilter) (sortKey, bool) {
+	switch field {
+	case "id":
+		return sortKey{field: field, column: "id", expr: "products.id", kind: valueInt,
+			value: func(p *entities.Product) any { return p.ID }}, true
+	case "name":
+		return sortKey{field: field, column: "name", expr: "name", kind: valueString,
+			value: func(p *entities.Product) any { return p.Name }}, true
+	case "price":
+		return sortKey{field: field, column: "price", expr: "price", kind: valueInt,
+			value: func(p *entities.Product) any { return p.Price.Amount }}, true
+	case "category":
+		return sortKey{field: field, column: "category", expr: "category", kind: valueString,
+			value: func(p *entities.Product) any { return p.Category }}, true
+	case "stock":
+		return sortKey{field: field, column: "stock", expr: "products.stock", kind: valueInt,
+			value: func(p *entities.Product) any { return p.Stock }}, true
+	case "created_at":
+		return sortKey{field: field, column: "created_at", expr: "created_at", kind: valueTime,
+			value: func(p *entities.Product) any { return p.CreatedAt }}, true
+	case "updated_at":
+		return sortKey{field: field, column: "updated_at", expr: "updated_at", kind: valueTime,
+			value: func(p *entities.Product) any { return p.UpdatedAt }}, true
+	case "total_stock":
+		return sortKey{field: field, column: "total_stock", expr: productTotalStockSQL, kind: valueInt,
+			value: func(p *entities.Product) any { return derefUint(p.TotalStock) }}, true
+	case "relevance":
+		// Relevance only has a meaning while a search is applied
+		tsQuery := searchQuery(filter)
+		if tsQuery == "" {
+			return sortKey{}, false
+		}
+		return sortKey{field: field, column: "search_rank", expr: "ts_rank(search_vector, to_tsquery('simple', ?))",
+			args: []any{tsQuery}, kind: valueFloat,
+			value: func(p *entities.Product) any { return derefFloat(p.SearchRank) }}, true
+	}
+	return sortKey{}, false
+}
+
+// productSortKeys resolves sortBy, a comma-separated list such as
+// "-stock,name", into ordering terms with id appended as a tiebreak, so the
+// order is total and keyset pages never skip or repeat rows. An empty
+// sortBy means best match first while a search is applied and newest first
+// otherwise; a q with no searchable words applies no search.
+func productSortKeys(sortBy string, filter *dto.ProductSearchFilter) ([]sortKey, error) {
+	if sortBy == "" {
+		sortBy = "-created_at"
+		if searchQuery(filter) != "" {
+			sortBy = "-relevance"
+		}
+	}
+
+	var keys []sortKey
+	seen := make(map[string]bool)
+	for _, term := range strings.Split(sortBy, ",") {
+		term = strings.TrimSpace(term)
+		desc := strings.HasPrefix(term, "-")
+		field := strings.TrimPrefix(term, "-")
+
+		key, ok := productSortField(field, filter)
+		if !ok || seen[field] {
+			return nil, fmt.Errorf("%w: %s", err_util.ErrInvalidSortField, term)
+		}
+		seen[field] = true
+		key.desc = desc
+		keys = append(keys, key)
+	}
+
+	if !seen["id"] {
+		tiebreak, _ := productSortField("id", filter)
+		tiebreak.desc = keys[len(keys)-1].desc
+		keys = append(keys, tiebreak)
+	}
+	return keys, nil
+}
+
+// orderClause renders keys as ORDER BY terms, flipped when reverse is set.
+func orderClause(keys []sortKey, reverse bool) string {
+	terms := make([]string, len(keys))
+	for i, k := range keys {
+		direction := "ASC"
+		if k.desc != reverse {
+			direction = "DESC"
+		}
+		terms[i] = k.column + " " + direction
+	}
+	return strings.Join(terms, ", ")
+}
+
+// selectProductColumns adds the computed columns that the search and the
+// sort keys read back onto the product.
+func selectProductColumns(query *gorm.DB, filter *dto.ProductSearchFilter, keys []sortKey) *gorm.DB {
+	var (
+		columns []string
+		args    []any
+	)
+
+	if tsQuery := searchQuery(filter); tsQuery != "" {
+		options := fmt.Sprintf("StartSel=%s,StopSel=%s,HighlightAll=true", entities.SearchHighlightStart, entities.SearchHighlightStop)
+		columns = append(columns,
+			"ts_rank(search_vector, to_tsquery('simple', ?)) AS search_rank",
+			"ts_headline('simple', name, to_tsquery('simple', ?), ?) AS name_highlight",
+			"ts_headline('simple', category, to_tsquery('simple', ?), ?) AS category_highlight",
+		)
+		args = append(args, tsQuery, tsQuery, options, tsQuery, options)
+	}
+
+	for _, k := range keys {
+		if k.field == "total_stock" {
+			columns = append(columns, productTotalStockSQL+" AS total_stock")
+		}
+	}
+
+	if len(columns) == 0 {
+		return query
+	}
+	return query.Select("products.*, "+strings.Join(columns, ", "), args...)
+}
+
+// keysetCondition selects the rows that come after values in the order of
+// keys, or before them when backward is set. Keys may mix directions, so
+// the condition is spelled out term by term rather than as a row
+// comparison.
+func keysetCondition(keys []sortKey, values []any, backward bool) (string, []any) {
+	var (
+		branches []string
+		args     []any
+	)
+	for i, k := range keys {
+		terms := make([]string, 0, i+1)
+		for j, prev := range keys[:i] {
+			terms = append(terms, prev.expr+" = ?")
+			args = append(append(args, prev.args...), values[j])
+		}
+
+		op := ">"
+		if k.desc != backward {
+			op = "<"
+		}
+		terms = append(terms, k.expr+" "+op+" ?")
+		args = append(append(args, k.args...), values[i])
+
+		branches = append(branches, "("+strings.Join(terms, " AND ")+")")
+	}
+	return "(" + strings.Join(branches, " OR ") + ")", args
+}
+
+// productCursor is the decoded form of the opaque cursor. Sort records the
+// ordering it was issued for, so a cursor cannot be replayed against a
+// different one.
+type productCursor struct {
+	Sort     string            `json:"s"`
+	Backward bool              `json:"b,omitempty"`
+	Values   []json.RawMessage `json:"v"`
+}
+
+func cursorSignature(keys []sortKey) string {
+	terms := make([]string, len(keys))
+	for i, k := range keys {
+		terms[i] = k.field
+		if k.desc {
+			terms[i] = "-" + k.field
+		}
+	}
+	return strings.Join(terms, ",")
+}
+
+func encodeCursor(keys []sortKey, p *entities.Product, backward bool) string {
+	c := productCursor{Sort: cursorSignature(keys), Backward: backward}
+	for _, k := range keys {
+		raw, _ := json.Marshal(k.value(p))
+		c.Values = append(c.Values, raw)
+	}
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(raw string, keys []sortKey) ([]any, bool, error) {
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, false, err_util.ErrInvalidCursor
+	}
+
+	var c productCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, false, err_util.ErrInvalidCursor
+	}
+	if c.Sort != cursorSignature(keys) || len(c.Values) != len(keys) {
+		return nil, false, err_util.ErrInvalidCursor
+	}
+
+	values := make([]any, len(keys))
+	for i, k := range keys {
+		var err error
+		switch k.kind {
+		case valueString:
+			var v string
+			err = json.Unmarshal(c.Values[i], &v)
+			values[i] = v
+		case valueInt:
+			var v int64
+			err = json.Unmarshal(c.Values[i], &v)
+			values[i] = v
+		case valueFloat:
+			var v float64
+			err = json.Unmarshal(c.Values[i], &v)
+			values[i] = v
+		case valueTime:
+			var v time.Time
+			err = json.Unmarshal(c.Values[i], &v)
+			values[i] = v
+		}
+		if err != nil {
+			return nil, false, err_util.ErrInvalidCursor
+		}
+	}
+	return values, c.Backward, nil
+}
+
+func derefUint(v *uint) uint {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func derefFloat(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
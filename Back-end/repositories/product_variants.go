@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-manager/entities"
+
+	err_util "product-manager/utils/error"
+
+	"gorm.io/gorm"
+)
+
+type ProductVariantRepository interface {
+	Create(ctx context.Context, variant *entities.ProductVariant) error
+	GetByID(ctx context.Context, productID, id uint) (*entities.ProductVariant, error)
+	GetByProductIDs(ctx context.Context, productIDs []uint) ([]entities.ProductVariant, error)
+	Update(ctx context.Context, variant *entities.ProductVariant) error
+	UpdateStock(ctx context.Context, id uint, stock uint) error
+	Delete(ctx context.Context, productID, id uint) error
+}
+
+type productVariantRepository struct {
+	db *gorm.DB
+}
+
+func NewProductVariantRepository(db *gorm.DB) ProductVariantRepository {
+	return &productVariantRepository{
+		db: db,
+	}
+}
+
+func (r *productVariantRepository) Create(ctx context.Context, variant *entities.ProductVariant) error {
+	if err := r.checkUnique(ctx, variant); err != nil {
+		return err
+	}
+
+	if err := getDB(ctx, r.db).Create(variant).Error; err != nil {
+		return fmt.Errorf("failed to create variant: %w", err)
+	}
+	return nil
+}
+
+func (r *productVariantRepository) GetByID(ctx context.Context, productID, id uint) (*entities.ProductVariant, error) {
+	if id == 0 {
+		return nil, err_util.ErrInvalidVariantID
+	}
+
+	var variant entities.ProductVariant
+	err := getDB(ctx, r.db).Where("product_id = ?", productID).First(&variant, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrVariantNotFound
+		}
+		return nil, fmt.Errorf("failed to get variant by ID: %w", err)
+	}
+	return &variant, nil
+}
+
+func (r *productVariantRepository) GetByProductIDs(ctx context.Context, productIDs []uint) ([]entities.ProductVariant, error) {
+	var variants []entities.ProductVariant
+	if len(productIDs) == 0 {
+		return variants, nil
+	}
+
+	err := getDB(ctx, r.db).
+		Where("product_id IN ?", productIDs).
+		Order("product_id ASC, id ASC").
+		Find(&variants).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variants: %w", err)
+	}
+	return variants, nil
+}
+
+func (r *productVariantRepository) Update(ctx context.Context, variant *entities.ProductVariant) error {
+	if err := r.checkUnique(ctx, variant); err != nil {
+		return err
+	}
+
+	// Select every column so clearing the price override and stock 0 are
+	// written too
+	result := getDB(ctx, r.db).
+		Model(variant).
+		Select("sku", "options", "options_key", "price", "stock").
+		Updates(variant)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update variant: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return err_util.ErrVariantNotFound
+	}
+	return nil
+}
+
+func (r *productVariantRepository) UpdateStock(ctx context.Context, id uint, stock uint) error {
+	result := getDB(ctx, r.db).Model(&entities.ProductVariant{}).Where("id = ?", id).Update("stock", stock)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update variant stock: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return err_util.ErrVariantNotFound
+	}
+	return nil
+}
+
+func (r *productVariantRepository) Delete(ctx context.Context, productID, id uint) error {
+	result := getDB(ctx, r.db).Where("product_id = ?", productID).Delete(&entities.ProductVariant{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete variant: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return err_util.ErrVariantNotFound
+	}
+	return nil
+}
+
+// checkUnique rejects a SKU used by any other variant and an option set
+// already taken within the same product.
+func (r *productVariantRepository) checkUnique(ctx context.Context, variant *entities.ProductVariant) error {
+	db := getDB(ctx, r.db)
+
+	var count int64
+	err := db.Model(&entities.ProductVariant{}).
+		Where("sku = ? AND id <> ?", variant.SKU, variant.ID).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to check variant SKU existence: %w", err)
+	}
+	if count > 0 {
+		return err_util.ErrVariantSKUAlreadyExists
+	}
+
+	err = db.Model(&entities.ProductVariant{}).
+		Where("product_id = ? AND options_key = ? AND id <> ?", variant.ProductID, variant.OptionsKey, variant.ID).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to check variant options existence: %w", err)
+	}
+	if count > 0 {
+		return err_util.ErrVariantOptionsAlreadyExist
+	}
+	return nil
+}
@@ -5,30 +5,51 @@ import (
 	"errors"
 	"fmt"
 	"product-manager/entities"
+	"slices"
 	"strings"
+	"time"
+	"unicode"
 
 	dto_base "product-manager/dto/base"
 	dto "product-manager/dto/products"
 	err_util "product-manager/utils/error"
+	"product-manager/utils/money"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepository interface {
 	Create(ctx context.Context, product *entities.Product) error
 	GetByID(ctx context.Context, id uint) (*entities.Product, error)
+	GetByIDForUpdate(ctx context.Context, id uint) (*entities.Product, error)
+	UpdateStock(ctx context.Context, id uint, stock uint) error
+	UpdatePrice(ctx context.Context, id uint, price money.Money) error
+	UpdateCostPrice(ctx context.Context, id uint, cost money.Money) error
+	GetCostPrices(ctx context.Context, ids []uint) (map[uint]money.Money, error)
 	GetAll(ctx context.Context, pagination *dto_base.PaginationRequest, filter *dto.ProductSearchFilter) ([]entities.Product, int64, error)
+	GetAllByCursor(ctx context.Context, pagination *dto_base.PaginationRequest, filter *dto.ProductSearchFilter) (products []entities.Product, next, prev string, err error)
+	CountByCategory(ctx context.Context, filter *dto.ProductSearchFilter) ([]entities.CategoryCount, error)
+	GetPriceRange(ctx context.Context, filter *dto.ProductSearchFilter) (*entities.PriceRange, error)
+	CountByPriceBucket(ctx context.Context, filter *dto.ProductSearchFilter, width uint) ([]entities.PriceBucketCount, error)
+	CountByStock(ctx context.Context, filter *dto.ProductSearchFilter) (*entities.StockCount, error)
+	GetStockLevel(ctx context.Context, id uint) (*entities.StockLevel, error)
+	GetLowStock(ctx context.Context, pagination *dto_base.PaginationRequest) ([]entities.StockLevel, int64, error)
+	Stream(ctx context.Context, sortBy string, filter *dto.ProductSearchFilter, fn func(product *entities.Product) error) error
 	Update(ctx context.Context, id uint, product *entities.Product) error
-	Delete(ctx context.Context, id uint) error
+	Delete(ctx context.Context, id uint, version uint) error
 	ExistsByName(ctx context.Context, name string, excludeID ...uint) (bool, error)
+	FindByNames(ctx context.Context, names []string) ([]entities.Product, error)
+	GetTrashed(ctx context.Context, pagination *dto_base.PaginationRequest) ([]entities.Product, int64, error)
+	GetTrashedByID(ctx context.Context, id uint) (*entities.Product, error)
+	Restore(ctx context.Context, id uint) error
+	Purge(ctx context.Context, id uint) error
 }
 
 type productRepository struct {
 	db *gorm.DB
 }
 
-
-
 func NewProductRepository(db *gorm.DB) ProductRepository {
 	return &productRepository{
 		db: db,
@@ -52,7 +73,7 @@ func (r *productRepository) Create(ctx context.Context, product *entities.Produc
 		return err_util.ErrProductAlreadyExists
 	}
 
-	if err := r.db.WithContext(ctx).Create(product).Error; err != nil {
+	if err := getDB(ctx, r.db).Create(product).Error; err != nil {
 		return fmt.Errorf("failed to create product: %w", err)
 	}
 
@@ -69,7 +90,7 @@ func (r *productRepository) GetByID(ctx context.Context, id uint) (*entities.Pro
 	}
 
 	var product entities.Product
-	err := r.db.WithContext(ctx).First(&product, id).Error
+	err := getDB(ctx, r.db).First(&product, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err_util.ErrProductNotFound
@@ -80,15 +101,134 @@ func (r *productRepository) GetByID(ctx context.Context, id uint) (*entities.Pro
 	return &product, nil
 }
 
-func (r *productRepository) GetAll(ctx context.Context,pagination *dto_base.PaginationRequest,filter *dto.ProductSearchFilter,) ([]entities.Product, int64, error) {
+// GetByIDForUpdate locks the product row until the surrounding transaction ends.
+func (r *productRepository) GetByIDForUpdate(ctx context.Context, id uint) (*entities.Product, error) {
+	if err := r.validateContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if id == 0 {
+		return nil, err_util.ErrInvalidProductID
+	}
+
+	var product entities.Product
+	err := getDB(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to lock product: %w", err)
+	}
+
+	return &product, nil
+}
+
+func (r *productRepository) UpdateStock(ctx context.Context, id uint, stock uint) error {
+	if err := r.validateContext(ctx); err != nil {
+		return err
+	}
+
+	result := getDB(ctx, r.db).
+		Model(&entities.Product{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"stock": stock, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update product stock: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return err_util.ErrProductNotFound
+	}
+
+	return nil
+}
+
+func (r *productRepository) UpdatePrice(ctx context.Context, id uint, price money.Money) error {
 	if err := r.validateContext(ctx); err != nil {
+		return err
+	}
+
+	result := getDB(ctx, r.db).
+		Model(&entities.Product{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"price": price.Amount, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update product price: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return err_util.ErrProductNotFound
+	}
+
+	return nil
+}
+
+// UpdateCostPrice leaves the version alone: the cost is not part of the
+// product callers edit, so changing it cannot conflict with their writes.
+func (r *productRepository) UpdateCostPrice(ctx context.Context, id uint, cost money.Money) error {
+	if err := r.validateContext(ctx); err != nil {
+		return err
+	}
+
+	result := getDB(ctx, r.db).
+		Model(&entities.Product{}).
+		Where("id = ?", id).
+		UpdateColumn("cost_price", cost.Amount)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update product cost price: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return err_util.ErrProductNotFound
+	}
+
+	return nil
+}
+
+// GetCostPrices returns the cost price of each of the given products that
+// has one.
+func (r *productRepository) GetCostPrices(ctx context.Context, ids []uint) (map[uint]money.Money, error) {
+	if err := r.validateContext(ctx); err != nil {
+		return nil, err
+	}
+
+	costs := make(map[uint]money.Money, len(ids))
+	if len(ids) == 0 {
+		return costs, nil
+	}
+
+	var products []entities.Product
+	err := getDB(ctx, r.db).
+		Select("id", "cost_price").
+		Where("id IN ? AND cost_price IS NOT NULL", ids).
+		Find(&products).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product cost prices: %w", err)
+	}
+
+	for _, p := range products {
+		costs[p.ID] = *p.CostPrice
+	}
+	return costs, nil
+}
+
+func (r *productRepository) GetAll(ctx context.Context, pagination *dto_base.PaginationRequest, filter *dto.ProductSearchFilter) ([]entities.Product, int64, error) {
+	if err := r.validateContext(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	keys, err := productSortKeys(pagination.SortBy, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := validateConditions(filter); err != nil {
 		return nil, 0, err
 	}
 
 	var products []entities.Product
 	var totalCount int64
 
-	countQuery := r.db.WithContext(ctx).Model(&entities.Product{})
+	countQuery := getDB(ctx, r.db).Model(&entities.Product{})
 	countQuery = r.applyFilters(countQuery, filter)
 	if err := countQuery.Count(&totalCount).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to count products: %w", err)
@@ -96,16 +236,13 @@ func (r *productRepository) GetAll(ctx context.Context,pagination *dto_base.Pagi
 
 	offset := (pagination.Page - 1) * pagination.Limit
 
-	query := r.db.WithContext(ctx).
-	Model(&entities.Product{}).
-	Order(parseSortBy(pagination.SortBy)).
-	Limit(pagination.Limit).
-	Offset(offset)
-
-	
+	query := getDB(ctx, r.db).
+		Model(&entities.Product{}).
+		Order(orderClause(keys, false)).
+		Limit(pagination.Limit).
+		Offset(offset)
 
-
-	query = r.applyFilters(query, filter)
+	query = selectProductColumns(r.applyFilters(query, filter), filter, keys)
 
 	if err := query.Find(&products).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to get products: %w", err)
@@ -114,7 +251,114 @@ func (r *productRepository) GetAll(ctx context.Context,pagination *dto_base.Pagi
 	return products, totalCount, nil
 }
 
+// GetAllByCursor pages with keyset conditions instead of OFFSET, so deep
+// pages cost the same as the first and concurrent inserts never shift rows
+// between pages. An empty pagination.Cursor starts from the beginning; next
+// and prev are empty when there is nothing further in that direction.
+func (r *productRepository) GetAllByCursor(ctx context.Context, pagination *dto_base.PaginationRequest, filter *dto.ProductSearchFilter) ([]entities.Product, string, string, error) {
+	if err := r.validateContext(ctx); err != nil {
+		return nil, "", "", err
+	}
 
+	keys, err := productSortKeys(pagination.SortBy, filter)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if err := validateConditions(filter); err != nil {
+		return nil, "", "", err
+	}
+
+	var (
+		after    []any
+		backward bool
+	)
+	if pagination.Cursor != nil && *pagination.Cursor != "" {
+		if after, backward, err = decodeCursor(*pagination.Cursor, keys); err != nil {
+			return nil, "", "", err
+		}
+	}
+
+	query := r.applyFilters(getDB(ctx, r.db).Model(&entities.Product{}), filter)
+	query = selectProductColumns(query, filter, keys)
+	if after != nil {
+		condition, args := keysetCondition(keys, after, backward)
+		query = query.Where(condition, args...)
+	}
+
+	// One extra row tells whether another page follows
+	var products []entities.Product
+	err = query.Order(orderClause(keys, backward)).Limit(pagination.Limit + 1).Find(&products).Error
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to get products: %w", err)
+	}
+
+	hasMore := len(products) > pagination.Limit
+	if hasMore {
+		products = products[:pagination.Limit]
+	}
+	if backward {
+		slices.Reverse(products)
+	}
+	if len(products) == 0 {
+		return products, "", "", nil
+	}
+
+	first, last := &products[0], &products[len(products)-1]
+	var next, prev string
+	if backward {
+		next = encodeCursor(keys, last, false)
+		if hasMore {
+			prev = encodeCursor(keys, first, true)
+		}
+	} else {
+		if hasMore {
+			next = encodeCursor(keys, last, false)
+		}
+		if after != nil {
+			prev = encodeCursor(keys, first, true)
+		}
+	}
+
+	return products, next, prev, nil
+}
+
+// Stream walks every product matching filter through a database cursor,
+// handing rows to fn one at a time instead of loading the result set.
+func (r *productRepository) Stream(ctx context.Context, sortBy string, filter *dto.ProductSearchFilter, fn func(product *entities.Product) error) error {
+	if err := r.validateContext(ctx); err != nil {
+		return err
+	}
+
+	keys, err := productSortKeys(sortBy, filter)
+	if err != nil {
+		return err
+	}
+	if err := validateConditions(filter); err != nil {
+		return err
+	}
+
+	db := getDB(ctx, r.db)
+	query := selectProductColumns(r.applyFilters(db.Model(&entities.Product{}), filter), filter, keys).
+		Order(orderClause(keys, false))
+
+	rows, err := query.Rows()
+	if err != nil {
+		return fmt.Errorf("failed to stream products: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var product entities.Product
+		if err := db.ScanRows(rows, &product); err != nil {
+			return fmt.Errorf("failed to scan product: %w", err)
+		}
+		if err := fn(&product); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
 
 func (r *productRepository) Update(ctx context.Context, id uint, product *entities.Product) error {
 	if err := r.validateContext(ctx); err != nil {
@@ -146,19 +390,32 @@ func (r *productRepository) Update(ctx context.Context, id uint, product *entiti
 		}
 	}
 
-	result := r.db.WithContext(ctx).Model(&entities.Product{}).Where("id = ?", id).Updates(product)
+	// product.Version is the version the caller read; the row only matches
+	// while nobody else has written it since.
+	expectedVersion := product.Version
+	if expectedVersion == 0 {
+		expectedVersion = existingProduct.Version
+	}
+	product.Version = expectedVersion + 1
+
+	// Select every column so zero values such as stock 0 are written too
+	result := getDB(ctx, r.db).
+		Model(&entities.Product{}).
+		Where("id = ? AND version = ?", id, expectedVersion).
+		Select("name", "category", "category_id", "price", "stock", "tax_class_id", "reorder_point", "reorder_quantity", "version").
+		Updates(product)
 	if result.Error != nil {
 		return fmt.Errorf("failed to update product: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		return err_util.ErrProductNotFound
+		return err_util.ErrProductVersionConflict
 	}
 
 	return nil
 }
 
-func (r *productRepository) Delete(ctx context.Context, id uint) error {
+func (r *productRepository) Delete(ctx context.Context, id uint, version uint) error {
 	if err := r.validateContext(ctx); err != nil {
 		return err
 	}
@@ -172,13 +429,16 @@ func (r *productRepository) Delete(ctx context.Context, id uint) error {
 		return err
 	}
 
-	result := r.db.WithContext(ctx).Delete(&entities.Product{}, id)
+	// Bumping the version with the delete keeps an ETag taken before it from
+	// matching once the product is restored
+	result := getDB(ctx, r.db).Model(&entities.Product{}).Where("id = ? AND version = ?", id, version).
+		Updates(map[string]any{"deleted_at": time.Now(), "version": gorm.Expr("version + 1")})
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete product: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		return err_util.ErrProductNotFound
+		return err_util.ErrProductVersionConflict
 	}
 
 	return nil
@@ -193,8 +453,8 @@ func (r *productRepository) ExistsByName(ctx context.Context, name string, exclu
 		return false, nil
 	}
 
-	query := r.db.WithContext(ctx).Model(&entities.Product{}).Where("LOWER(name) = LOWER(?)", strings.TrimSpace(name))
-	
+	query := getDB(ctx, r.db).Model(&entities.Product{}).Where("LOWER(name) = LOWER(?)", strings.TrimSpace(name))
+
 	if len(excludeID) > 0 && excludeID[0] > 0 {
 		query = query.Where("id != ?", excludeID[0])
 	}
@@ -207,6 +467,125 @@ func (r *productRepository) ExistsByName(ctx context.Context, name string, exclu
 	return count > 0, nil
 }
 
+// FindByNames returns live products whose name matches any of names,
+// ignoring case.
+func (r *productRepository) FindByNames(ctx context.Context, names []string) ([]entities.Product, error) {
+	if err := r.validateContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	lowered := make([]string, len(names))
+	for i, name := range names {
+		lowered[i] = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	var products []entities.Product
+	if err := getDB(ctx, r.db).Where("LOWER(name) IN ?", lowered).Find(&products).Error; err != nil {
+		return nil, fmt.Errorf("failed to find products by name: %w", err)
+	}
+
+	return products, nil
+}
+
+func (r *productRepository) GetTrashed(ctx context.Context, pagination *dto_base.PaginationRequest) ([]entities.Product, int64, error) {
+	if err := r.validateContext(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	var products []entities.Product
+	var totalCount int64
+
+	trashed := getDB(ctx, r.db).Unscoped().Model(&entities.Product{}).Where("deleted_at IS NOT NULL")
+	if err := trashed.Count(&totalCount).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count trashed products: %w", err)
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	query := getDB(ctx, r.db).
+		Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Limit(pagination.Limit).
+		Offset(offset)
+
+	if err := query.Find(&products).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get trashed products: %w", err)
+	}
+
+	return products, totalCount, nil
+}
+
+func (r *productRepository) GetTrashedByID(ctx context.Context, id uint) (*entities.Product, error) {
+	if err := r.validateContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if id == 0 {
+		return nil, err_util.ErrInvalidProductID
+	}
+
+	var product entities.Product
+	err := getDB(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").First(&product, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrProductNotInTrash
+		}
+		return nil, fmt.Errorf("failed to get trashed product by ID: %w", err)
+	}
+
+	return &product, nil
+}
+
+func (r *productRepository) Restore(ctx context.Context, id uint) error {
+	product, err := r.GetTrashedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// A live product may have taken the name while this one was in the trash
+	exists, err := r.ExistsByName(ctx, product.Name, id)
+	if err != nil {
+		return fmt.Errorf("failed to check product name existence: %w", err)
+	}
+	if exists {
+		return err_util.ErrProductAlreadyExists
+	}
+
+	result := getDB(ctx, r.db).Unscoped().Model(&entities.Product{}).Where("id = ?", id).
+		Updates(map[string]any{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore product: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return err_util.ErrProductNotInTrash
+	}
+
+	return nil
+}
+
+func (r *productRepository) Purge(ctx context.Context, id uint) error {
+	if _, err := r.GetTrashedByID(ctx, id); err != nil {
+		return err
+	}
+
+	result := getDB(ctx, r.db).Unscoped().Delete(&entities.Product{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to purge product: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return err_util.ErrProductNotInTrash
+	}
+
+	return nil
+}
+
 func (r *productRepository) validateContext(ctx context.Context) error {
 	if ctx == nil {
 		return errors.New("context is required")
@@ -217,17 +596,27 @@ func (r *productRepository) validateContext(ctx context.Context) error {
 	return nil
 }
 
+const maxSearchWords = 10
+
+// productTotalStockSQL is the stock a product can sell: the sum over its
+// variants when it has any, its own stock otherwise.
+const productTotalStockSQL = "COALESCE((SELECT SUM(v.stock) FROM product_variants v WHERE v.product_id = products.id), products.stock)"
+
 func (r *productRepository) applyFilters(query *gorm.DB, filter *dto.ProductSearchFilter) *gorm.DB {
 	if filter == nil {
 		return query
 	}
 
+	if tsQuery := searchQuery(filter); tsQuery != "" {
+		query = query.Where("search_vector @@ to_tsquery('simple', ?)", tsQuery)
+	}
+
 	if filter.Name != "" {
 		query = query.Where("name ILIKE ?", "%"+filter.Name+"%")
 	}
 
-	if filter.Category != "" {
-		query = query.Where("category ILIKE ?", "%"+filter.Category+"%")
+	if len(filter.CategoryIDs) > 0 || len(filter.CategorySlugs) > 0 {
+		query = applyCategoryFilter(query, filter)
 	}
 
 	if filter.MinPrice != nil {
@@ -240,40 +629,67 @@ func (r *productRepository) applyFilters(query *gorm.DB, filter *dto.ProductSear
 
 	if filter.InStock != nil {
 		if *filter.InStock {
-			query = query.Where("stock > 0")
+			query = query.Where(productTotalStockSQL + " > 0")
 		} else {
-			query = query.Where("stock = 0")
+			query = query.Where(productTotalStockSQL + " = 0")
 		}
 	}
 
-	return query
-}
+	if filter.SKU != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM product_variants v WHERE v.product_id = products.id AND v.sku = ?)", filter.SKU)
+	}
 
-func parseSortBy(raw string) string {
-	if raw == "" {
-		return "created_at DESC"
+	if filter.VariantInStock != nil {
+		inStockVariant := "EXISTS (SELECT 1 FROM product_variants v WHERE v.product_id = products.id AND v.stock > 0)"
+		if *filter.VariantInStock {
+			query = query.Where(inStockVariant)
+		} else {
+			query = query.Where("NOT " + inStockVariant)
+		}
 	}
 
-	direction := "ASC"
-	field := raw
+	return applyConditions(query, filter.Conditions)
+}
+
+// searchQuery turns the free-text q parameter into a tsquery that requires
+// every word, each as a prefix so results appear while the user is still
+// typing. Only letters and digits survive, so no input can form tsquery
+// syntax.
+func searchQuery(filter *dto.ProductSearchFilter) string {
+	if filter == nil {
+		return ""
+	}
 
-	if raw[0] == '-' {
-		direction = "DESC"
-		field = raw[1:]
+	words := strings.FieldsFunc(strings.ToLower(filter.Query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > maxSearchWords {
+		words = words[:maxSearchWords]
 	}
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	return strings.Join(words, " & ")
+}
 
-	allowed := map[string]bool{
-		"name": true,
-		"price": true,
-		"category": true,
-		"created_at": true,
+// applyCategoryFilter matches the categories named by ID or slug and, with
+// IncludeDescendants, their whole subtrees through the materialized path.
+func applyCategoryFilter(query *gorm.DB, filter *dto.ProductSearchFilter) *gorm.DB {
+	ids := filter.CategoryIDs
+	if ids == nil {
+		ids = []uint{}
+	}
+	slugs := make([]string, len(filter.CategorySlugs))
+	for i, slug := range filter.CategorySlugs {
+		slugs[i] = entities.Slugify(slug)
 	}
 
-	if !allowed[field] {
-		return "created_at DESC"
+	if !filter.IncludeDescendants {
+		return query.Where("category_id IN (SELECT id FROM categories WHERE id IN ? OR slug IN ?)", ids, slugs)
 	}
 
-	return fmt.Sprintf("%s %s", field, direction)
+	return query.Where(
+		"category_id IN (SELECT d.id FROM categories d JOIN categories c ON d.path LIKE c.path || '%' WHERE c.id IN ? OR c.slug IN ?)",
+		ids, slugs,
+	)
 }
-
-
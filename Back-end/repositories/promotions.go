@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-manager/entities"
+	"time"
+
+	err_util "product-manager/utils/error"
+
+	"gorm.io/gorm"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *entities.Promotion) error
+	GetByID(ctx context.Context, id uint) (*entities.Promotion, error)
+	GetAll(ctx context.Context, status string, now time.Time) ([]entities.Promotion, error)
+	GetActive(ctx context.Context, at time.Time) ([]entities.Promotion, error)
+	Update(ctx context.Context, promotion *entities.Promotion) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type promotionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) PromotionRepository {
+	return &promotionRepository{
+		db: db,
+	}
+}
+
+func (r *promotionRepository) Create(ctx context.Context, promotion *entities.Promotion) error {
+	if err := getDB(ctx, r.db).Create(promotion).Error; err != nil {
+		return fmt.Errorf("failed to create promotion: %w", err)
+	}
+	return nil
+}
+
+func (r *promotionRepository) GetByID(ctx context.Context, id uint) (*entities.Promotion, error) {
+	if id == 0 {
+		return nil, err_util.ErrInvalidPromotionID
+	}
+
+	var promotion entities.Promotion
+	if err := getDB(ctx, r.db).First(&promotion, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrPromotionNotFound
+		}
+		return nil, fmt.Errorf("failed to get promotion by ID: %w", err)
+	}
+	return &promotion, nil
+}
+
+// GetAll returns the promotions with the given status at now, or every
+// promotion when status is empty, latest start first.
+func (r *promotionRepository) GetAll(ctx context.Context, status string, now time.Time) ([]entities.Promotion, error) {
+	query := getDB(ctx, r.db)
+	switch status {
+	case entities.PromotionStatusScheduled:
+		query = query.Where("starts_at > ?", now)
+	case entities.PromotionStatusActive:
+		query = query.Where("starts_at <= ? AND ends_at > ?", now, now)
+	case entities.PromotionStatusEnded:
+		query = query.Where("ends_at <= ?", now)
+	}
+
+	var promotions []entities.Promotion
+	if err := query.Order("starts_at DESC, id DESC").Find(&promotions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get promotions: %w", err)
+	}
+	return promotions, nil
+}
+
+// GetActive returns the promotions running at at in the order the engine
+// applies them: highest priority first, then oldest first.
+func (r *promotionRepository) GetActive(ctx context.Context, at time.Time) ([]entities.Promotion, error) {
+	var promotions []entities.Promotion
+	err := getDB(ctx, r.db).
+		Where("starts_at <= ? AND ends_at > ?", at, at).
+		Order("priority DESC, id ASC").
+		Find(&promotions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active promotions: %w", err)
+	}
+	return promotions, nil
+}
+
+func (r *promotionRepository) Update(ctx context.Context, promotion *entities.Promotion) error {
+	result := getDB(ctx, r.db).
+		Model(promotion).
+		Select("name", "description", "type", "value", "scope", "target_ids", "priority", "stacking", "starts_at", "ends_at").
+		Updates(promotion)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update promotion: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return err_util.ErrPromotionNotFound
+	}
+	return nil
+}
+
+func (r *promotionRepository) Delete(ctx context.Context, id uint) error {
+	result := getDB(ctx, r.db).Delete(&entities.Promotion{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete promotion: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return err_util.ErrPromotionNotFound
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-manager/entities"
+
+	dto_base "product-manager/dto/base"
+	err_util "product-manager/utils/error"
+
+	"gorm.io/gorm"
+)
+
+// stockLevels selects every live product's total stock next to its reorder
+// settings, falling back to the category's where the product has none.
+func stockLevels(db *gorm.DB) *gorm.DB {
+	return db.Model(&entities.Product{}).
+		Select(
+			"products.id AS product_id, products.name, products.category_id, " +
+				productTotalStockSQL + " AS stock, " +
+				"COALESCE(products.reorder_point, c.reorder_point) AS reorder_point, " +
+				"COALESCE(products.reorder_quantity, c.reorder_quantity) AS reorder_quantity",
+		).
+		Joins("LEFT JOIN categories c ON c.id = products.category_id")
+}
+
+func (r *productRepository) GetStockLevel(ctx context.Context, id uint) (*entities.StockLevel, error) {
+	var level entities.StockLevel
+	err := stockLevels(getDB(ctx, r.db)).Where("products.id = ?", id).Take(&level).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get stock level: %w", err)
+	}
+	return &level, nil
+}
+
+// GetLowStock lists the products at or below their reorder point, the ones
+// with the least stock relative to it first.
+func (r *productRepository) GetLowStock(ctx context.Context, pagination *dto_base.PaginationRequest) ([]entities.StockLevel, int64, error) {
+	db := getDB(ctx, r.db)
+	low := db.Table("(?) AS l", stockLevels(db)).Where("l.reorder_point IS NOT NULL AND l.stock <= l.reorder_point")
+
+	var total int64
+	if err := low.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count low stock products: %w", err)
+	}
+
+	var levels []entities.StockLevel
+	err := low.
+		Order("l.stock::numeric / GREATEST(l.reorder_point, 1) ASC, l.reorder_point - l.stock DESC, l.product_id ASC").
+		Limit(pagination.Limit).
+		Offset((pagination.Page - 1) * pagination.Limit).
+		Find(&levels).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get low stock products: %w", err)
+	}
+	return levels, total, nil
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"product-manager/entities"
+
+	dto_base "product-manager/dto/base"
+
+	"gorm.io/gorm"
+)
+
+type StockMovementRepository interface {
+	Create(ctx context.Context, movement *entities.StockMovement) error
+	GetByProductID(ctx context.Context, productID uint, pagination *dto_base.PaginationRequest) ([]entities.StockMovement, int64, error)
+	GetLedger(ctx context.Context, productID uint) ([]entities.StockMovement, error)
+	SumByProductID(ctx context.Context, productID uint) (int64, error)
+	GetDiscrepancies(ctx context.Context) ([]entities.StockLedgerBalance, error)
+}
+
+type stockMovementRepository struct {
+	db *gorm.DB
+}
+
+func NewStockMovementRepository(db *gorm.DB) StockMovementRepository {
+	return &stockMovementRepository{
+		db: db,
+	}
+}
+
+func (r *stockMovementRepository) Create(ctx context.Context, movement *entities.StockMovement) error {
+	if err := getDB(ctx, r.db).Create(movement).Error; err != nil {
+		return fmt.Errorf("failed to create stock movement: %w", err)
+	}
+	return nil
+}
+
+func (r *stockMovementRepository) GetByProductID(ctx context.Context, productID uint, pagination *dto_base.PaginationRequest) ([]entities.StockMovement, int64, error) {
+	var movements []entities.StockMovement
+	var totalCount int64
+
+	query := getDB(ctx, r.db).Model(&entities.StockMovement{}).Where("product_id = ?", productID)
+	if err := query.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count stock movements: %w", err)
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(pagination.Limit).
+		Offset(offset).
+		Find(&movements).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get stock movements: %w", err)
+	}
+
+	return movements, totalCount, nil
+}
+
+// GetLedger returns every movement of a product in the order it happened.
+func (r *stockMovementRepository) GetLedger(ctx context.Context, productID uint) ([]entities.StockMovement, error) {
+	var movements []entities.StockMovement
+	err := getDB(ctx, r.db).
+		Where("product_id = ?", productID).
+		Order("created_at ASC, id ASC").
+		Find(&movements).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock ledger: %w", err)
+	}
+	return movements, nil
+}
+
+func (r *stockMovementRepository) SumByProductID(ctx context.Context, productID uint) (int64, error) {
+	var sum int64
+	err := getDB(ctx, r.db).
+		Model(&entities.StockMovement{}).
+		Where("product_id = ?", productID).
+		Select("COALESCE(SUM(quantity), 0)").
+		Scan(&sum).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum stock movements: %w", err)
+	}
+	return sum, nil
+}
+
+// heldStockSQL is all the stock a product row p holds: its own plus that of
+// its variants, each kept by its own movements.
+const heldStockSQL = "p.stock + COALESCE((SELECT SUM(v.stock) FROM product_variants v WHERE v.product_id = p.id), 0)"
+
+func (r *stockMovementRepository) GetDiscrepancies(ctx context.Context) ([]entities.StockLedgerBalance, error) {
+	var balances []entities.StockLedgerBalance
+	err := getDB(ctx, r.db).
+		Table("products AS p").
+		Select("p.id AS product_id, " + heldStockSQL + " AS cached_stock, COALESCE(SUM(m.quantity), 0) AS ledger_stock").
+		Joins("LEFT JOIN stock_movements AS m ON m.product_id = p.id").
+		Where("p.deleted_at IS NULL").
+		Group("p.id, p.stock").
+		Having(heldStockSQL + " <> COALESCE(SUM(m.quantity), 0)").
+		Order("p.id").
+		Scan(&balances).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile stock movements: %w", err)
+	}
+	return balances, nil
+}
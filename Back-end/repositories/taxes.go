@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-manager/entities"
+	"time"
+
+	err_util "product-manager/utils/error"
+
+	"gorm.io/gorm"
+)
+
+type TaxRepository interface {
+	Create(ctx context.Context, class *entities.TaxClass) error
+	GetByID(ctx context.Context, id uint) (*entities.TaxClass, error)
+	GetAll(ctx context.Context) ([]entities.TaxClass, error)
+	Update(ctx context.Context, class *entities.TaxClass) error
+	Delete(ctx context.Context, id uint) error
+	ExistsByName(ctx context.Context, name string, excludeID ...uint) (bool, error)
+
+	CreateRate(ctx context.Context, rate *entities.TaxRate) error
+	GetRate(ctx context.Context, classID, id uint) (*entities.TaxRate, error)
+	GetRates(ctx context.Context, classID uint) ([]entities.TaxRate, error)
+	GetRatesAt(ctx context.Context, classIDs []uint, at time.Time) ([]entities.TaxRate, error)
+	DeleteRate(ctx context.Context, id uint) error
+}
+
+type taxRepository struct {
+	db *gorm.DB
+}
+
+func NewTaxRepository(db *gorm.DB) TaxRepository {
+	return &taxRepository{
+		db: db,
+	}
+}
+
+func (r *taxRepository) Create(ctx context.Context, class *entities.TaxClass) error {
+	if err := getDB(ctx, r.db).Create(class).Error; err != nil {
+		return fmt.Errorf("failed to create tax class: %w", err)
+	}
+	return nil
+}
+
+func (r *taxRepository) GetByID(ctx context.Context, id uint) (*entities.TaxClass, error) {
+	if id == 0 {
+		return nil, err_util.ErrInvalidTaxClassID
+	}
+
+	var class entities.TaxClass
+	if err := getDB(ctx, r.db).First(&class, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrTaxClassNotFound
+		}
+		return nil, fmt.Errorf("failed to get tax class by ID: %w", err)
+	}
+	return &class, nil
+}
+
+func (r *taxRepository) GetAll(ctx context.Context) ([]entities.TaxClass, error) {
+	var classes []entities.TaxClass
+	if err := getDB(ctx, r.db).Order("name ASC").Find(&classes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get tax classes: %w", err)
+	}
+	return classes, nil
+}
+
+func (r *taxRepository) Update(ctx context.Context, class *entities.TaxClass) error {
+	result := getDB(ctx, r.db).Model(class).Select("name", "description").Updates(class)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update tax class: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return err_util.ErrTaxClassNotFound
+	}
+	return nil
+}
+
+// Delete refuses classes still assigned to a product, trashed ones
+// included, since restoring the product would bring the class back.
+func (r *taxRepository) Delete(ctx context.Context, id uint) error {
+	return getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Unscoped().Model(&entities.Product{}).Where("tax_class_id = ?", id).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count tax class products: %w", err)
+		}
+		if count > 0 {
+			return err_util.ErrTaxClassInUse
+		}
+
+		result := tx.Delete(&entities.TaxClass{}, id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete tax class: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return err_util.ErrTaxClassNotFound
+		}
+		return nil
+	})
+}
+
+func (r *taxRepository) ExistsByName(ctx context.Context, name string, excludeID ...uint) (bool, error) {
+	query := getDB(ctx, r.db).Model(&entities.TaxClass{}).Where("LOWER(name) = LOWER(?)", name)
+	if len(excludeID) > 0 && excludeID[0] > 0 {
+		query = query.Where("id != ?", excludeID[0])
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check tax class existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *taxRepository) CreateRate(ctx context.Context, rate *entities.TaxRate) error {
+	var count int64
+	err := getDB(ctx, r.db).
+		Model(&entities.TaxRate{}).
+		Where("tax_class_id = ? AND effective_from = ?", rate.TaxClassID, rate.EffectiveFrom).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to check tax rate existence: %w", err)
+	}
+	if count > 0 {
+		return err_util.ErrTaxRateAlreadyExists
+	}
+
+	if err := getDB(ctx, r.db).Create(rate).Error; err != nil {
+		return fmt.Errorf("failed to create tax rate: %w", err)
+	}
+	return nil
+}
+
+func (r *taxRepository) GetRate(ctx context.Context, classID, id uint) (*entities.TaxRate, error) {
+	if id == 0 {
+		return nil, err_util.ErrInvalidTaxRateID
+	}
+
+	var rate entities.TaxRate
+	err := getDB(ctx, r.db).Where("tax_class_id = ?", classID).First(&rate, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrTaxRateNotFound
+		}
+		return nil, fmt.Errorf("failed to get tax rate by ID: %w", err)
+	}
+	return &rate, nil
+}
+
+// GetRates returns every rate of a class, oldest first.
+func (r *taxRepository) GetRates(ctx context.Context, classID uint) ([]entities.TaxRate, error) {
+	var rates []entities.TaxRate
+	err := getDB(ctx, r.db).
+		Where("tax_class_id = ?", classID).
+		Order("effective_from ASC").
+		Find(&rates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax rates: %w", err)
+	}
+	return rates, nil
+}
+
+// GetRatesAt returns the rate in effect at at for each of the given classes
+// that has one.
+func (r *taxRepository) GetRatesAt(ctx context.Context, classIDs []uint, at time.Time) ([]entities.TaxRate, error) {
+	var rates []entities.TaxRate
+	if len(classIDs) == 0 {
+		return rates, nil
+	}
+
+	err := getDB(ctx, r.db).
+		Select("DISTINCT ON (tax_class_id) *").
+		Where("tax_class_id IN ? AND effective_from <= ?", classIDs, at).
+		Order("tax_class_id ASC, effective_from DESC").
+		Find(&rates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax rates in effect: %w", err)
+	}
+	return rates, nil
+}
+
+func (r *taxRepository) DeleteRate(ctx context.Context, id uint) error {
+	result := getDB(ctx, r.db).Delete(&entities.TaxRate{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete tax rate: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return err_util.ErrTaxRateNotFound
+	}
+	return nil
+}
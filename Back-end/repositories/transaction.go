@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type TxManager interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	AfterCommit(ctx context.Context, fn func())
+}
+
+type txKey struct{}
+
+type afterCommitKey struct{}
+
+// afterCommitHooks collects the callbacks registered during the outermost
+// transaction.
+type afterCommitHooks struct {
+	fns []func()
+}
+
+type txManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) TxManager {
+	return &txManager{
+		db: db,
+	}
+}
+
+// WithTransaction runs fn inside a transaction carried by the returned context.
+// Nested calls reuse the outer transaction through a savepoint.
+func (m *txManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks); ok {
+		// Callbacks registered under a savepoint that rolls back must not run
+		mark := len(hooks.fns)
+		err := getDB(ctx, m.db).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, tx))
+		})
+		if err != nil {
+			hooks.fns = hooks.fns[:mark]
+		}
+		return err
+	}
+
+	hooks := &afterCommitHooks{}
+	err := getDB(ctx, m.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(context.WithValue(ctx, txKey{}, tx), afterCommitKey{}, hooks))
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, fn := range hooks.fns {
+		fn()
+	}
+	return nil
+}
+
+// AfterCommit runs fn once the transaction carried by ctx has committed, and
+// drops it if the transaction rolls back. Outside a transaction fn runs
+// right away.
+func (m *txManager) AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks); ok {
+		hooks.fns = append(hooks.fns, fn)
+		return
+	}
+	fn()
+}
+
+// getDB returns the transaction bound to ctx, or db when there is none.
+func getDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
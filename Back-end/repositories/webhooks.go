@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-manager/entities"
+	"time"
+
+	dto_base "product-manager/dto/base"
+	err_util "product-manager/utils/error"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, subscription *entities.WebhookSubscription) error
+	GetByID(ctx context.Context, id uint) (*entities.WebhookSubscription, error)
+	GetAll(ctx context.Context) ([]entities.WebhookSubscription, error)
+	GetActiveByEvent(ctx context.Context, event string) ([]entities.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *entities.WebhookSubscription) error
+	Delete(ctx context.Context, id uint) error
+
+	CreateDeliveries(ctx context.Context, deliveries []entities.WebhookDelivery) error
+	GetDelivery(ctx context.Context, subscriptionID, id uint) (*entities.WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, subscriptionID uint, status string, pagination *dto_base.PaginationRequest) ([]entities.WebhookDelivery, int64, error)
+	GetAttempts(ctx context.Context, deliveryID uint) ([]entities.WebhookAttempt, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entities.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt) error
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	if err := getDB(ctx, r.db).Create(subscription).Error; err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, id uint) (*entities.WebhookSubscription, error) {
+	if id == 0 {
+		return nil, err_util.ErrInvalidWebhookID
+	}
+
+	var subscription entities.WebhookSubscription
+	if err := getDB(ctx, r.db).First(&subscription, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook by ID: %w", err)
+	}
+	return &subscription, nil
+}
+
+func (r *webhookRepository) GetAll(ctx context.Context) ([]entities.WebhookSubscription, error) {
+	var subscriptions []entities.WebhookSubscription
+	if err := getDB(ctx, r.db).Order("id ASC").Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (r *webhookRepository) GetActiveByEvent(ctx context.Context, event string) ([]entities.WebhookSubscription, error) {
+	var subscriptions []entities.WebhookSubscription
+	err := getDB(ctx, r.db).
+		Where("active AND events @> ?", entities.WebhookEvents{event}).
+		Order("id ASC").
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks for %s: %w", event, err)
+	}
+	return subscriptions, nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	result := getDB(ctx, r.db).
+		Model(subscription).
+		Select("url", "secret", "events", "description", "active").
+		Updates(subscription)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update webhook: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return err_util.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id uint) error {
+	result := getDB(ctx, r.db).Delete(&entities.WebhookSubscription{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return err_util.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []entities.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if err := getDB(ctx, r.db).Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, subscriptionID, id uint) (*entities.WebhookDelivery, error) {
+	if id == 0 {
+		return nil, err_util.ErrInvalidWebhookDeliveryID
+	}
+
+	var delivery entities.WebhookDelivery
+	err := getDB(ctx, r.db).Where("subscription_id = ?", subscriptionID).First(&delivery, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err_util.ErrWebhookDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery by ID: %w", err)
+	}
+	return &delivery, nil
+}
+
+// GetDeliveries lists a subscription's deliveries newest first, optionally
+// only those in status.
+func (r *webhookRepository) GetDeliveries(ctx context.Context, subscriptionID uint, status string, pagination *dto_base.PaginationRequest) ([]entities.WebhookDelivery, int64, error) {
+	query := getDB(ctx, r.db).Model(&entities.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	var deliveries []entities.WebhookDelivery
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(pagination.Limit).
+		Offset((pagination.Page - 1) * pagination.Limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	return deliveries, total, nil
+}
+
+func (r *webhookRepository) GetAttempts(ctx context.Context, deliveryID uint) ([]entities.WebhookAttempt, error) {
+	var attempts []entities.WebhookAttempt
+	err := getDB(ctx, r.db).Where("delivery_id = ?", deliveryID).Order("attempt ASC").Find(&attempts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+// ClaimDueDeliveries picks up to limit pending deliveries whose attempt is
+// due and pushes their next attempt out by lease, so other workers leave
+// them alone while this one sends them. A worker that dies mid-send only
+// delays the delivery until the lease runs out. Deliveries of inactive
+// subscriptions wait until they are enabled again.
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entities.WebhookDelivery, error) {
+	var deliveries []entities.WebhookDelivery
+	err := getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Joins("Subscription").
+			Where("webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ?", entities.WebhookDeliveryPending, time.Now()).
+			Where(`"Subscription".active`).
+			Order("webhook_deliveries.next_attempt_at ASC").
+			Limit(limit).
+			Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "webhook_deliveries"}, Options: "SKIP LOCKED"}).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(deliveries))
+		for i, d := range deliveries {
+			ids[i] = d.ID
+		}
+		return tx.Model(&entities.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			UpdateColumn("next_attempt_at", time.Now().Add(lease)).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// RecordAttempt logs attempt and saves the delivery's new state with it.
+func (r *webhookRepository) RecordAttempt(ctx context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt) error {
+	return getDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attempt).Error; err != nil {
+			return fmt.Errorf("failed to record webhook attempt: %w", err)
+		}
+		err := tx.Model(delivery).
+			Select("status", "attempts", "next_attempt_at", "last_status_code", "last_error").
+			Updates(delivery).Error
+		if err != nil {
+			return fmt.Errorf("failed to update webhook delivery: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,23 @@
+package categories
+
+import (
+	"product-manager/controllers"
+	"product-manager/repositories"
+	"product-manager/usecases"
+	"product-manager/utils/token"
+	"product-manager/utils/validation"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+func InitCategoriesRoute(e *echo.Echo, db *gorm.DB, v *validation.Validator) {
+	repo := repositories.NewCategoryRepository(db)
+	usecase := usecases.NewCategoryUseCase(repo)
+	controller := controllers.NewCategoryController(usecase, v)
+
+	group := e.Group("/api/v1")
+	group.Use(echojwt.WithConfig(token.GetJWTConfig()), token.ClaimsToContext())
+	controller.RegisterRoutes(group)
+}
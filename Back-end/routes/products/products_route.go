@@ -1,23 +1,82 @@
 package products
 
 import (
+	"context"
+	"log"
 	"product-manager/controllers"
+	"product-manager/drivers/storage"
 	"product-manager/repositories"
 	"product-manager/usecases"
 	"product-manager/utils/token"
 	"product-manager/utils/validation"
 
+	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
-	echojwt "github.com/labstack/echo-jwt/v4"
 )
 
-func InitProductsRoute(e *echo.Echo, db *gorm.DB, v *validation.Validator) {
+// InitProductsRoute also starts the price scheduler, which applies scheduled
+// prices for as long as the server runs. Before any route can move stock it
+// backfills cost layers for products whose ledger predates them.
+func InitProductsRoute(e *echo.Echo, db *gorm.DB, v *validation.Validator, store storage.Storage, outbox *usecases.Outbox, tax usecases.TaxConfig) {
 	repo := repositories.NewProductRepository(db)
-	usecase := usecases.NewProductUseCase(repo)
+	auditRepo := repositories.NewProductAuditRepository(db)
+	stockRepo := repositories.NewStockMovementRepository(db)
+	layerRepo := repositories.NewCostLayerRepository(db)
+	priceRepo := repositories.NewProductPriceRepository(db)
+	listRepo := repositories.NewProductCurrencyPriceRepository(db)
+	rateRepo := repositories.NewExchangeRateRepository(db)
+	promoRepo := repositories.NewPromotionRepository(db)
+	taxRepo := repositories.NewTaxRepository(db)
+	categoryRepo := repositories.NewCategoryRepository(db)
+	variantRepo := repositories.NewProductVariantRepository(db)
+	imageRepo := repositories.NewProductImageRepository(db)
+	adminRepo := repositories.NewAdminRepository(db)
+	txManager := repositories.NewTxManager(db)
+
+	usecase := usecases.NewProductUseCase(repo, auditRepo, stockRepo, layerRepo, priceRepo, listRepo, rateRepo, promoRepo, taxRepo, categoryRepo, variantRepo, imageRepo, store, txManager, outbox, tax)
 	controller := controllers.NewProductController(usecase, v)
 
+	auditUseCase := usecases.NewProductAuditUseCase(auditRepo)
+	auditController := controllers.NewProductAuditController(auditUseCase, v)
+
+	stockUseCase := usecases.NewStockMovementUseCase(stockRepo, repo, variantRepo, layerRepo, txManager, outbox)
+	stockController := controllers.NewStockMovementController(stockUseCase, v)
+
+	variantUseCase := usecases.NewProductVariantUseCase(variantRepo, repo, stockRepo, layerRepo, promoRepo, categoryRepo, txManager, outbox)
+	variantController := controllers.NewProductVariantController(variantUseCase, v)
+
+	imageUseCase := usecases.NewProductImageUseCase(imageRepo, repo, store, txManager)
+	imageController := controllers.NewProductImageController(imageUseCase, v)
+
+	priceScheduler := usecases.NewPriceScheduler(priceRepo, repo, auditRepo, txManager, outbox)
+	go priceScheduler.Run(context.Background())
+	priceUseCase := usecases.NewProductPriceUseCase(priceRepo, repo, txManager, priceScheduler)
+	priceController := controllers.NewProductPriceController(priceUseCase, v)
+
+	listUseCase := usecases.NewProductCurrencyPriceUseCase(listRepo, repo)
+	listController := controllers.NewProductCurrencyPriceController(listUseCase, v)
+
+	marginUseCase := usecases.NewMarginUseCase(repo, taxRepo, txManager, tax)
+	marginController := controllers.NewMarginController(marginUseCase, v)
+
+	valuationUseCase := usecases.NewInventoryValuationUseCase(layerRepo, stockRepo, txManager)
+	if filled, err := valuationUseCase.Backfill(context.Background()); err != nil {
+		log.Printf("cost layers: backfill stopped after %d products: %v", filled, err)
+	} else if filled > 0 {
+		log.Printf("cost layers: backfilled %d products", filled)
+	}
+	valuationController := controllers.NewInventoryValuationController(valuationUseCase, v)
+
 	group := e.Group("/api/v1")
-	group.Use(echojwt.WithConfig(token.GetJWTConfig()))
+	group.Use(echojwt.WithConfig(token.GetJWTConfig()), token.ClaimsToContext(), token.RefreshPermissions(adminRepo.GetPermissions))
 	controller.RegisterRoutes(group)
-}
\ No newline at end of file
+	auditController.RegisterRoutes(group)
+	stockController.RegisterRoutes(group)
+	marginController.RegisterRoutes(group)
+	valuationController.RegisterRoutes(group)
+	variantController.RegisterRoutes(group)
+	imageController.RegisterRoutes(group)
+	priceController.RegisterRoutes(group)
+	listController.RegisterRoutes(group)
+}
@@ -0,0 +1,25 @@
+package promotions
+
+import (
+	"product-manager/controllers"
+	"product-manager/repositories"
+	"product-manager/usecases"
+	"product-manager/utils/token"
+	"product-manager/utils/validation"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+func InitPromotionsRoute(e *echo.Echo, db *gorm.DB, v *validation.Validator) {
+	repo := repositories.NewPromotionRepository(db)
+	productRepo := repositories.NewProductRepository(db)
+	categoryRepo := repositories.NewCategoryRepository(db)
+	usecase := usecases.NewPromotionUseCase(repo, productRepo, categoryRepo)
+	controller := controllers.NewPromotionController(usecase, v)
+
+	group := e.Group("/api/v1")
+	group.Use(echojwt.WithConfig(token.GetJWTConfig()), token.ClaimsToContext())
+	controller.RegisterRoutes(group)
+}
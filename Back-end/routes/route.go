@@ -1,15 +1,38 @@
 package routes
 
 import (
-	"product-manager/routes/products"
+	"context"
+	"product-manager/drivers/storage"
+	"product-manager/repositories"
 	"product-manager/routes/admin"
+	"product-manager/routes/categories"
+	"product-manager/routes/currencies"
+	"product-manager/routes/products"
+	"product-manager/routes/promotions"
+	"product-manager/routes/taxes"
+	"product-manager/routes/webhooks"
+	"product-manager/usecases"
+	"product-manager/utils/events"
 	"product-manager/utils/validation"
 
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
 )
 
-func InitRoute(e *echo.Echo, db *gorm.DB, v *validation.Validator) {
+// InitRoute also starts the outbox dispatcher, which hands the events the
+// routes record to the log, to bus and to webhook subscriptions.
+func InitRoute(e *echo.Echo, db *gorm.DB, v *validation.Validator, store storage.Storage, bus *events.Bus, tax usecases.TaxConfig) {
+	outbox := usecases.NewOutbox(repositories.NewOutboxRepository(db), repositories.NewTxManager(db))
+	outbox.AddSink(usecases.LogSink())
+	outbox.AddSink(usecases.BusSink(bus))
+
 	admin.InitAdminRoute(e, db, v)
-	products.InitProductsRoute(e, db, v)
-}
\ No newline at end of file
+	products.InitProductsRoute(e, db, v, store, outbox, tax)
+	categories.InitCategoriesRoute(e, db, v)
+	currencies.InitCurrenciesRoute(e, db, v)
+	promotions.InitPromotionsRoute(e, db, v)
+	taxes.InitTaxesRoute(e, db, v)
+	webhooks.InitWebhooksRoute(e, db, v, outbox)
+
+	go outbox.Run(context.Background())
+}
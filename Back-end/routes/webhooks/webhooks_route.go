@@ -0,0 +1,30 @@
+package webhooks
+
+import (
+	"context"
+	"product-manager/controllers"
+	"product-manager/repositories"
+	"product-manager/usecases"
+	"product-manager/utils/token"
+	"product-manager/utils/validation"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// InitWebhooksRoute also adds the dispatcher to outbox as a sink and starts
+// it, so deliveries go out for as long as the server runs.
+func InitWebhooksRoute(e *echo.Echo, db *gorm.DB, v *validation.Validator, outbox *usecases.Outbox) {
+	repo := repositories.NewWebhookRepository(db)
+	dispatcher := usecases.NewWebhookDispatcher(repo, repositories.NewTxManager(db))
+	outbox.AddSink(dispatcher)
+	go dispatcher.Run(context.Background())
+
+	usecase := usecases.NewWebhookUseCase(repo, dispatcher)
+	controller := controllers.NewWebhookController(usecase, v)
+
+	group := e.Group("/api/v1")
+	group.Use(echojwt.WithConfig(token.GetJWTConfig()), token.ClaimsToContext())
+	controller.RegisterRoutes(group)
+}
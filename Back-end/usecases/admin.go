@@ -61,7 +61,7 @@ func (uc *adminUseCase) Login(ctx context.Context, req *admin.AdminRequest) (*ad
 		return nil, err
 	}
 
-	tokenStr, err := uc.tokenUtil.GenerateToken(adminRecord.ID, adminRecord.Username)
+	tokenStr, err := uc.tokenUtil.GenerateToken(adminRecord.ID, adminRecord.Username, adminRecord.Permissions)
 	if err != nil {
 		return nil, err
 	}
@@ -81,9 +81,10 @@ func (uc *adminUseCase) Fetch(ctx context.Context, id uuid.UUID) (*admin.AdminRe
 
 func (uc *adminUseCase) mapToResponse(a *entities.Admin) *admin.AdminResponse {
 	return &admin.AdminResponse{
-		ID:       a.ID.String(),
-		Username: a.Username,
-		Email:    a.Email,
-		Token:    a.Token,
+		ID:          a.ID.String(),
+		Username:    a.Username,
+		Email:       a.Email,
+		Token:       a.Token,
+		Permissions: a.Permissions,
 	}
 }
\ No newline at end of file
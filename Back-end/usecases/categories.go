@@ -0,0 +1,184 @@
+package usecases
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	dto "product-manager/dto/categories"
+	"product-manager/entities"
+	"product-manager/repositories"
+)
+
+type CategoryUseCase interface {
+	Create(ctx context.Context, req *dto.CategoryRequest) (*dto.CategoryResponse, error)
+	Get(ctx context.Context, idOrSlug string) (*dto.CategoryResponse, error)
+	GetAll(ctx context.Context) ([]dto.CategoryResponse, error)
+	GetTree(ctx context.Context) ([]dto.CategoryTreeNode, error)
+	Update(ctx context.Context, id uint, req *dto.CategoryRequest) (*dto.CategoryResponse, error)
+	Move(ctx context.Context, id uint, req *dto.CategoryMoveRequest) (*dto.CategoryResponse, error)
+	Delete(ctx context.Context, id uint) error
+}
+
+type categoryUseCase struct {
+	repo repositories.CategoryRepository
+}
+
+func NewCategoryUseCase(repo repositories.CategoryRepository) CategoryUseCase {
+	return &categoryUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *categoryUseCase) Create(ctx context.Context, req *dto.CategoryRequest) (*dto.CategoryResponse, error) {
+	category := &entities.Category{
+		Name:            strings.TrimSpace(req.Name),
+		Slug:            categorySlug(req),
+		ParentID:        req.ParentID,
+		ReorderPoint:    req.ReorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
+	}
+
+	if err := uc.repo.Create(ctx, category); err != nil {
+		return nil, err
+	}
+	return uc.mapToResponse(category), nil
+}
+
+func (uc *categoryUseCase) Get(ctx context.Context, idOrSlug string) (*dto.CategoryResponse, error) {
+	category, err := findCategory(ctx, uc.repo, idOrSlug)
+	if err != nil {
+		return nil, err
+	}
+	return uc.mapToResponse(category), nil
+}
+
+func (uc *categoryUseCase) GetAll(ctx context.Context) ([]dto.CategoryResponse, error) {
+	categories, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.CategoryResponse, len(categories))
+	for i, c := range categories {
+		res[i] = *uc.mapToResponse(&c)
+	}
+	return res, nil
+}
+
+// GetTree returns every category nested under its parent, siblings in
+// position order.
+func (uc *categoryUseCase) GetTree(ctx context.Context) ([]dto.CategoryTreeNode, error) {
+	categories, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := uc.repo.CountProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[uint][]entities.Category)
+	var roots []entities.Category
+	for _, c := range categories {
+		if c.ParentID == nil {
+			roots = append(roots, c)
+		} else {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+		}
+	}
+
+	var build func(nodes []entities.Category) []dto.CategoryTreeNode
+	build = func(nodes []entities.Category) []dto.CategoryTreeNode {
+		res := make([]dto.CategoryTreeNode, len(nodes))
+		for i, c := range nodes {
+			node := dto.CategoryTreeNode{
+				ID:                 c.ID,
+				Name:               c.Name,
+				Slug:               c.Slug,
+				Position:           c.Position,
+				DirectProductCount: counts[c.ID],
+				Children:           build(children[c.ID]),
+			}
+			node.TotalProductCount = node.DirectProductCount
+			for _, child := range node.Children {
+				node.TotalProductCount += child.TotalProductCount
+			}
+			res[i] = node
+		}
+		return res
+	}
+
+	return build(roots), nil
+}
+
+func (uc *categoryUseCase) Update(ctx context.Context, id uint, req *dto.CategoryRequest) (*dto.CategoryResponse, error) {
+	category, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	category.Name = strings.TrimSpace(req.Name)
+	category.Slug = categorySlug(req)
+	category.ReorderPoint = req.ReorderPoint
+	category.ReorderQuantity = req.ReorderQuantity
+
+	if err := uc.repo.Update(ctx, category); err != nil {
+		return nil, err
+	}
+	return uc.mapToResponse(category), nil
+}
+
+func (uc *categoryUseCase) Move(ctx context.Context, id uint, req *dto.CategoryMoveRequest) (*dto.CategoryResponse, error) {
+	position := -1
+	if req.Position != nil {
+		position = *req.Position
+	}
+
+	if err := uc.repo.Move(ctx, id, req.ParentID, position); err != nil {
+		return nil, err
+	}
+
+	category, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.mapToResponse(category), nil
+}
+
+func (uc *categoryUseCase) Delete(ctx context.Context, id uint) error {
+	return uc.repo.Delete(ctx, id)
+}
+
+func (uc *categoryUseCase) mapToResponse(c *entities.Category) *dto.CategoryResponse {
+	return &dto.CategoryResponse{
+		ID:              c.ID,
+		Name:            c.Name,
+		Slug:            c.Slug,
+		ParentID:        c.ParentID,
+		Path:            c.Path,
+		Position:        c.Position,
+		ReorderPoint:    c.ReorderPoint,
+		ReorderQuantity: c.ReorderQuantity,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+// categorySlug uses the requested slug as given, so a malformed one is
+// rejected rather than silently rewritten, and derives one from the name
+// otherwise.
+func categorySlug(req *dto.CategoryRequest) string {
+	if req.Slug != "" {
+		return req.Slug
+	}
+	return entities.Slugify(req.Name)
+}
+
+// findCategory looks a category up by numeric ID, falling back to its slug.
+func findCategory(ctx context.Context, repo repositories.CategoryRepository, idOrSlug string) (*entities.Category, error) {
+	if id, err := strconv.ParseUint(idOrSlug, 10, 64); err == nil {
+		return repo.GetByID(ctx, uint(id))
+	}
+	return repo.GetBySlug(ctx, entities.Slugify(idOrSlug))
+}
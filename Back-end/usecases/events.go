@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"product-manager/entities"
+	"product-manager/repositories"
+)
+
+// watchStockLevel reads a product's stock level ahead of a change and
+// returns the check to run once the change is written. The check records
+// stock.changed when the sellable stock moved, and stock.low as well when
+// it went down to the reorder point. Both calls must happen inside the same
+// transaction, with the product row locked.
+func watchStockLevel(ctx context.Context, productRepo repositories.ProductRepository, outbox *Outbox, productID uint) (func(ctx context.Context) error, error) {
+	before, err := productRepo.GetStockLevel(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		after, err := productRepo.GetStockLevel(ctx, productID)
+		if err != nil {
+			return err
+		}
+		if after.Stock != before.Stock {
+			err := outbox.Record(ctx, entities.EventStockChanged, &entities.StockChange{
+				ProductID: productID,
+				Before:    before.Stock,
+				After:     after.Stock,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if after.CrossedLow(before) {
+			return outbox.Record(ctx, entities.EventStockLow, after)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	dto "product-manager/dto/currencies"
+	"product-manager/entities"
+	"product-manager/repositories"
+	err_util "product-manager/utils/error"
+	"product-manager/utils/money"
+)
+
+var maxExchangeRate = big.NewRat(10_000_000_000, 1)
+
+type ExchangeRateUseCase interface {
+	GetAll(ctx context.Context) ([]dto.ExchangeRateResponse, error)
+	Set(ctx context.Context, base, quote string, req *dto.ExchangeRateRequest) (*dto.ExchangeRateResponse, error)
+	Delete(ctx context.Context, base, quote string) error
+}
+
+type exchangeRateUseCase struct {
+	repo repositories.ExchangeRateRepository
+}
+
+func NewExchangeRateUseCase(repo repositories.ExchangeRateRepository) ExchangeRateUseCase {
+	return &exchangeRateUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *exchangeRateUseCase) GetAll(ctx context.Context) ([]dto.ExchangeRateResponse, error) {
+	rates, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.ExchangeRateResponse, len(rates))
+	for i, r := range rates {
+		res[i] = *mapExchangeRateToResponse(&r)
+	}
+	return res, nil
+}
+
+func (uc *exchangeRateUseCase) Set(ctx context.Context, base, quote string, req *dto.ExchangeRateRequest) (*dto.ExchangeRateResponse, error) {
+	from, to, err := currencyPair(base, quote)
+	if err != nil {
+		return nil, err
+	}
+	ratio, err := money.ParseRate(req.Rate.String())
+	if err != nil {
+		return nil, err
+	}
+	// The column holds 10 digits either side of the point
+	stored := money.FormatRate(ratio)
+	if ratio.Cmp(maxExchangeRate) >= 0 || stored == "0" {
+		return nil, err_util.ErrInvalidExchangeRate
+	}
+
+	rate := &entities.ExchangeRate{Base: from, Quote: to, Rate: stored}
+	if err := uc.repo.Save(ctx, rate); err != nil {
+		return nil, err
+	}
+	return mapExchangeRateToResponse(rate), nil
+}
+
+func (uc *exchangeRateUseCase) Delete(ctx context.Context, base, quote string) error {
+	from, to, err := currencyPair(base, quote)
+	if err != nil {
+		return err
+	}
+	return uc.repo.Delete(ctx, from, to)
+}
+
+// currencyPair checks both currencies of a rate and returns their codes.
+func currencyPair(base, quote string) (string, string, error) {
+	from, err := money.Lookup(base)
+	if err != nil {
+		return "", "", err
+	}
+	to, err := money.Lookup(quote)
+	if err != nil {
+		return "", "", err
+	}
+	if from.Code == to.Code {
+		return "", "", err_util.ErrSameCurrencyRate
+	}
+	return from.Code, to.Code, nil
+}
+
+// findRate returns the rate from one currency to another, inverting the
+// rate for the opposite pair when only that one is set.
+func findRate(ctx context.Context, repo repositories.ExchangeRateRepository, from, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	rate, err := repo.Get(ctx, from, to)
+	if err == nil {
+		return rate.Ratio()
+	}
+	if !errors.Is(err, err_util.ErrExchangeRateNotFound) {
+		return nil, err
+	}
+
+	inverse, err := repo.Get(ctx, to, from)
+	if err != nil {
+		if errors.Is(err, err_util.ErrExchangeRateNotFound) {
+			return nil, fmt.Errorf("%w: %s/%s", err_util.ErrExchangeRateNotFound, from, to)
+		}
+		return nil, err
+	}
+	ratio, err := inverse.Ratio()
+	if err != nil {
+		return nil, err
+	}
+	return ratio.Inv(ratio), nil
+}
+
+func mapExchangeRateToResponse(r *entities.ExchangeRate) *dto.ExchangeRateResponse {
+	rate := r.Rate
+	if ratio, err := r.Ratio(); err == nil {
+		rate = money.FormatRate(ratio)
+	}
+	return &dto.ExchangeRateResponse{
+		Base:      r.Base,
+		Quote:     r.Quote,
+		Rate:      rate,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
@@ -0,0 +1,212 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	dto "product-manager/dto/reports"
+	"product-manager/entities"
+	"product-manager/repositories"
+	err_util "product-manager/utils/error"
+	"product-manager/utils/money"
+)
+
+type InventoryValuationUseCase interface {
+	GetValuation(ctx context.Context, at time.Time, method string) (*dto.InventoryValuation, error)
+	Backfill(ctx context.Context) (int, error)
+}
+
+type inventoryValuationUseCase struct {
+	layerRepo repositories.CostLayerRepository
+	stockRepo repositories.StockMovementRepository
+	txManager repositories.TxManager
+}
+
+func NewInventoryValuationUseCase(layerRepo repositories.CostLayerRepository, stockRepo repositories.StockMovementRepository, txManager repositories.TxManager) InventoryValuationUseCase {
+	return &inventoryValuationUseCase{
+		layerRepo: layerRepo,
+		stockRepo: stockRepo,
+		txManager: txManager,
+	}
+}
+
+// GetValuation values the stock on hand just before at, product by product,
+// then totals it by category and overall.
+func (uc *inventoryValuationUseCase) GetValuation(ctx context.Context, at time.Time, method string) (*dto.InventoryValuation, error) {
+	value := entities.ValueFIFO
+	switch method {
+	case entities.ValuationFIFO:
+	case entities.ValuationWeightedAverage:
+		value = entities.ValueWeightedAverage
+	default:
+		return nil, err_util.ErrInvalidValuationMethod
+	}
+
+	layers, err := uc.layerRepo.GetReceivedBefore(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+	consumptions, err := uc.layerRepo.GetConsumedBefore(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	// Layers come grouped by product, so each product's run is one slice
+	productOf := make(map[uint]uint, len(layers))
+	for _, l := range layers {
+		productOf[l.ID] = l.ProductID
+	}
+	consumed := make(map[uint][]entities.CostLayerConsumption)
+	for _, c := range consumptions {
+		if productID, ok := productOf[c.LayerID]; ok {
+			consumed[productID] = append(consumed[productID], c)
+		}
+	}
+
+	report := &dto.InventoryValuation{
+		AsOf:       at,
+		Method:     method,
+		Products:   []dto.ProductValuation{},
+		Categories: []dto.CategoryValuation{},
+		Total:      dto.ValuationTotals{Value: entities.BaseMoney(0)},
+	}
+	categories := make(map[uint]*dto.CategoryValuation)
+	for start := 0; start < len(layers); {
+		end := start + 1
+		for end < len(layers) && layers[end].ProductID == layers[start].ProductID {
+			end++
+		}
+		run := layers[start:end]
+		start = end
+
+		v := value(run, consumed[run[0].ProductID])
+		if v.Quantity == 0 && v.Uncosted == 0 {
+			continue
+		}
+		p := run[0].Product
+		valuation := dto.ProductValuation{
+			ProductID:        p.ID,
+			Name:             p.Name,
+			CategoryID:       p.CategoryID,
+			Category:         p.Category,
+			Quantity:         v.Quantity,
+			UnitCost:         unitCostOf(v),
+			Value:            v.Value,
+			UncostedQuantity: v.Uncosted,
+		}
+		report.Products = append(report.Products, valuation)
+
+		var key uint
+		if p.CategoryID != nil {
+			key = *p.CategoryID
+		}
+		category, ok := categories[key]
+		if !ok {
+			category = &dto.CategoryValuation{CategoryID: p.CategoryID, Category: p.Category}
+			categories[key] = category
+		}
+		addToValuation(&category.ValuationTotals, &valuation)
+		addToValuation(&report.Total, &valuation)
+	}
+
+	slices.SortFunc(report.Products, func(a, b dto.ProductValuation) int {
+		return cmp.Or(cmp.Compare(a.Category, b.Category), cmp.Compare(a.Name, b.Name), cmp.Compare(a.ProductID, b.ProductID))
+	})
+	for _, c := range categories {
+		report.Categories = append(report.Categories, *c)
+	}
+	slices.SortFunc(report.Categories, func(a, b dto.CategoryValuation) int {
+		return cmp.Compare(a.Category, b.Category)
+	})
+	return report, nil
+}
+
+// Backfill builds cost layers for the products whose stock ledger predates
+// them by replaying the ledger. Receipts keep the unit cost they were
+// recorded with, if any; other inbound stock takes the product's cost price
+// as it is now. Each product is replayed under its row lock and only while
+// it still has no layers, so instances starting together never replay a
+// ledger twice. It returns how many products were looked at.
+func (uc *inventoryValuationUseCase) Backfill(ctx context.Context) (int, error) {
+	products, err := uc.layerRepo.GetUnlayeredProducts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, p := range products {
+		err := uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+			// Another instance starting up, or a movement recorded since
+			// the list was read, may have layered the product already
+			unlayered, err := uc.layerRepo.LockIfUnlayered(ctx, p.ID)
+			if err != nil || !unlayered {
+				return err
+			}
+
+			movements, err := uc.stockRepo.GetLedger(ctx, p.ID)
+			if err != nil {
+				return err
+			}
+			for _, m := range movements {
+				if err := recordCostLayer(ctx, uc.layerRepo, &m, cmp.Or(m.UnitCost, p.CostPrice)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return i, fmt.Errorf("product %d: %w", p.ID, err)
+		}
+	}
+	return len(products), nil
+}
+
+// recordCostLayer keeps the cost layers of a product in step with a
+// movement already in its ledger: inbound stock opens a layer at unitCost
+// and outbound stock is taken from the oldest layers of the same variant, or
+// of the product itself. The caller holds the product lock, so layers are
+// never consumed twice.
+func recordCostLayer(ctx context.Context, repo repositories.CostLayerRepository, movement *entities.StockMovement, unitCost *money.Money) error {
+	if movement.Quantity > 0 {
+		return repo.Create(ctx, &entities.CostLayer{
+			ProductID:  movement.ProductID,
+			VariantID:  movement.VariantID,
+			MovementID: movement.ID,
+			UnitCost:   unitCost,
+			Quantity:   uint(movement.Quantity),
+			Remaining:  uint(movement.Quantity),
+			ReceivedAt: movement.CreatedAt,
+		})
+	}
+
+	layers, err := repo.GetOpen(ctx, movement.ProductID, movement.VariantID)
+	if err != nil {
+		return err
+	}
+	consumptions := entities.ConsumeFIFO(layers, uint(-movement.Quantity))
+	for i := range consumptions {
+		consumptions[i].MovementID = movement.ID
+		consumptions[i].ConsumedAt = movement.CreatedAt
+	}
+	return repo.Consume(ctx, consumptions)
+}
+
+func addToValuation(t *dto.ValuationTotals, v *dto.ProductValuation) {
+	t.ProductCount++
+	t.Quantity += v.Quantity
+	t.Value = t.Value.Add(v.Value)
+	t.UncostedQuantity += v.UncostedQuantity
+}
+
+// unitCostOf gives the average cost of a unit of the valued stock, rounded
+// to two decimals.
+func unitCostOf(v entities.StockValuation) *float64 {
+	if v.Quantity == 0 {
+		return nil
+	}
+	cost := math.Round(float64(v.Value.Amount)*100/float64(v.Quantity)) / 100
+	return &cost
+}
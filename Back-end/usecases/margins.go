@@ -0,0 +1,214 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	dto_products "product-manager/dto/products"
+	dto "product-manager/dto/reports"
+	"product-manager/entities"
+	"product-manager/repositories"
+	"product-manager/utils/money"
+)
+
+type MarginUseCase interface {
+	SetCostPrice(ctx context.Context, productID uint, req *dto.CostPriceRequest) (*dto.ProductMargin, error)
+	GetReport(ctx context.Context, sortBy string, filter *dto_products.ProductSearchFilter, margins *dto.MarginFilter) (*dto.MarginReport, error)
+}
+
+type marginUseCase struct {
+	productRepo repositories.ProductRepository
+	taxRepo     repositories.TaxRepository
+	txManager   repositories.TxManager
+	tax         TaxConfig
+}
+
+func NewMarginUseCase(productRepo repositories.ProductRepository, taxRepo repositories.TaxRepository, txManager repositories.TxManager, tax TaxConfig) MarginUseCase {
+	return &marginUseCase{
+		productRepo: productRepo,
+		taxRepo:     taxRepo,
+		txManager:   txManager,
+		tax:         tax,
+	}
+}
+
+// SetCostPrice overrides the cost price receipts have built up, for stock
+// counted in or costs corrected by hand. Later receipts average from it.
+func (uc *marginUseCase) SetCostPrice(ctx context.Context, productID uint, req *dto.CostPriceRequest) (*dto.ProductMargin, error) {
+	cost, err := inBaseCurrency(req.CostPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	var product *entities.Product
+	err = uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// The lock keeps a receipt from averaging into the old cost meanwhile
+		var err error
+		product, err = uc.productRepo.GetByIDForUpdate(ctx, productID)
+		if err != nil {
+			return err
+		}
+		return uc.productRepo.UpdateCostPrice(ctx, productID, cost)
+	})
+	if err != nil {
+		return nil, err
+	}
+	product.CostPrice = &cost
+
+	rates, err := uc.ratesFor(ctx, []entities.Product{*product})
+	if err != nil {
+		return nil, err
+	}
+	margin := uc.marginOf(product, rates)
+	return &margin, nil
+}
+
+// GetReport measures every product matching filter that has a cost price,
+// in the order sortBy gives, then totals them by category and overall.
+// The margin bounds are applied before totalling, so the totals describe
+// the products listed.
+func (uc *marginUseCase) GetReport(ctx context.Context, sortBy string, filter *dto_products.ProductSearchFilter, margins *dto.MarginFilter) (*dto.MarginReport, error) {
+	report := &dto.MarginReport{
+		PricesIncludeTax: uc.tax.PRICES_INCLUDE_TAX,
+		Products:         []dto.ProductMargin{},
+		Categories:       []dto.CategoryMargin{},
+		Total: dto.MarginTotals{
+			NetPrice:    entities.BaseMoney(0),
+			CostPrice:   entities.BaseMoney(0),
+			GrossProfit: entities.BaseMoney(0),
+		},
+	}
+
+	var products []entities.Product
+	err := uc.productRepo.Stream(ctx, sortBy, filter, func(p *entities.Product) error {
+		if p.CostPrice == nil {
+			report.WithoutCost++
+			return nil
+		}
+		products = append(products, *p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := uc.ratesFor(ctx, products)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[uint]*dto.CategoryMargin)
+	for i := range products {
+		margin := uc.marginOf(&products[i], rates)
+		if !withinMargins(margin.MarginPercent, margins) {
+			continue
+		}
+		report.Products = append(report.Products, margin)
+
+		var key uint
+		if margin.CategoryID != nil {
+			key = *margin.CategoryID
+		}
+		category, ok := categories[key]
+		if !ok {
+			category = &dto.CategoryMargin{CategoryID: margin.CategoryID, Category: margin.Category}
+			categories[key] = category
+		}
+		addToTotals(&category.MarginTotals, &margin)
+		addToTotals(&report.Total, &margin)
+	}
+
+	for _, c := range categories {
+		finishTotals(&c.MarginTotals)
+		report.Categories = append(report.Categories, *c)
+	}
+	slices.SortFunc(report.Categories, func(a, b dto.CategoryMargin) int {
+		return cmp.Compare(a.Category, b.Category)
+	})
+	finishTotals(&report.Total)
+	return report, nil
+}
+
+// ratesFor returns the tax rate in effect now for the tax classes of
+// products, by class.
+func (uc *marginUseCase) ratesFor(ctx context.Context, products []entities.Product) (map[uint]uint, error) {
+	var ids []uint
+	for _, p := range products {
+		if p.TaxClassID != nil {
+			ids = append(ids, *p.TaxClassID)
+		}
+	}
+	slices.Sort(ids)
+
+	rates, err := uc.taxRepo.GetRatesAt(ctx, slices.Compact(ids), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[uint]uint, len(rates))
+	for _, r := range rates {
+		current[r.TaxClassID] = r.Rate
+	}
+	return current, nil
+}
+
+// marginOf measures a product that has a cost price. Costs are taken to be
+// net of tax, so the price is compared net too.
+func (uc *marginUseCase) marginOf(p *entities.Product, rates map[uint]uint) dto.ProductMargin {
+	var rate uint
+	if p.TaxClassID != nil {
+		rate = rates[*p.TaxClassID]
+	}
+	net, _, _ := entities.ApplyTax(p.Price, rate, uc.tax.PRICES_INCLUDE_TAX)
+	profit := net.Sub(*p.CostPrice)
+
+	return dto.ProductMargin{
+		ProductID:     p.ID,
+		Name:          p.Name,
+		CategoryID:    p.CategoryID,
+		Category:      p.Category,
+		Price:         p.Price,
+		NetPrice:      net,
+		CostPrice:     *p.CostPrice,
+		GrossProfit:   profit,
+		MarginPercent: percentOf(profit, net),
+		MarkupPercent: percentOf(profit, *p.CostPrice),
+	}
+}
+
+func withinMargins(margin *float64, margins *dto.MarginFilter) bool {
+	if margins.MinMargin == nil && margins.MaxMargin == nil {
+		return true
+	}
+	if margin == nil {
+		return false
+	}
+	if margins.MinMargin != nil && *margin < *margins.MinMargin {
+		return false
+	}
+	return margins.MaxMargin == nil || *margin <= *margins.MaxMargin
+}
+
+func addToTotals(t *dto.MarginTotals, m *dto.ProductMargin) {
+	t.ProductCount++
+	t.NetPrice = t.NetPrice.Add(m.NetPrice)
+	t.CostPrice = t.CostPrice.Add(m.CostPrice)
+	t.GrossProfit = t.GrossProfit.Add(m.GrossProfit)
+}
+
+func finishTotals(t *dto.MarginTotals) {
+	t.MarginPercent = percentOf(t.GrossProfit, t.NetPrice)
+	t.MarkupPercent = percentOf(t.GrossProfit, t.CostPrice)
+}
+
+// percentOf gives part as a percentage of whole rounded to two decimals,
+// or nil when whole is zero.
+func percentOf(part, whole money.Money) *float64 {
+	if whole.Amount == 0 {
+		return nil
+	}
+	percent := math.Round(float64(part.Amount)*10000/float64(whole.Amount)) / 100
+	return &percent
+}
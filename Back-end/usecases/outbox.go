@@ -0,0 +1,235 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"product-manager/entities"
+	"product-manager/repositories"
+	"product-manager/utils/events"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	outboxBatchSize       = 100
+	outboxMaxAttempts     = 10
+	outboxBaseBackoff     = time.Second
+	outboxMaxBackoff      = 5 * time.Minute
+	outboxPollInterval    = 2 * time.Second
+	outboxCleanupInterval = time.Hour
+	outboxRetention       = 24 * time.Hour
+)
+
+// EventSink is somewhere the outbox hands events to. Handle runs inside the
+// dispatch transaction, so a sink that writes through ctx commits together
+// with the event being marked dispatched. A sink may see an event more
+// than once and should use its ID to tell repeats apart.
+type EventSink interface {
+	Handle(ctx context.Context, event events.Event) error
+}
+
+type EventSinkFunc func(ctx context.Context, event events.Event) error
+
+func (f EventSinkFunc) Handle(ctx context.Context, event events.Event) error {
+	return f(ctx, event)
+}
+
+// LogSink writes every event to the log.
+func LogSink() EventSink {
+	return EventSinkFunc(func(ctx context.Context, event events.Event) error {
+		log.Printf("event %s %s: %s", event.Name, event.ID, event.Payload)
+		return nil
+	})
+}
+
+// BusSink publishes every event on bus. Payloads arrive as json.RawMessage.
+func BusSink(bus *events.Bus) EventSink {
+	return EventSinkFunc(func(ctx context.Context, event events.Event) error {
+		bus.Publish(ctx, event)
+		return nil
+	})
+}
+
+// Outbox records events in the transaction of the change they describe and
+// dispatches them to its sinks afterwards, so an event is neither lost when
+// the process dies after the commit nor sent for a change that rolled back.
+// Events go out in the order they were recorded; one that a sink keeps
+// failing holds back the rest until it is given up on after
+// outboxMaxAttempts.
+type Outbox struct {
+	repo      repositories.OutboxRepository
+	txManager repositories.TxManager
+	sinks     []EventSink
+	wake      chan struct{}
+	retryAt   time.Time
+}
+
+func NewOutbox(repo repositories.OutboxRepository, txManager repositories.TxManager) *Outbox {
+	return &Outbox{
+		repo:      repo,
+		txManager: txManager,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// AddSink registers sink for every event. Sinks must be added before Run.
+func (o *Outbox) AddSink(sink EventSink) {
+	o.sinks = append(o.sinks, sink)
+}
+
+// Record writes an event through the transaction carried by ctx, and wakes
+// the dispatcher once that transaction commits.
+func (o *Outbox) Record(ctx context.Context, name string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", name, err)
+	}
+
+	event := &entities.OutboxEvent{
+		EventID:    uuid.New(),
+		Name:       name,
+		Payload:    string(data),
+		OccurredAt: time.Now(),
+	}
+	if err := o.repo.Add(ctx, event); err != nil {
+		return err
+	}
+	o.txManager.AfterCommit(ctx, o.Wake)
+	return nil
+}
+
+// Wake makes Run look for pending events now rather than at its next poll.
+func (o *Outbox) Wake() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run dispatches pending events and clears out old dispatched ones until
+// ctx is done.
+func (o *Outbox) Run(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+	cleanup := time.NewTicker(outboxCleanupInterval)
+	defer cleanup.Stop()
+
+	for {
+		o.dispatchPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-o.wake:
+		case <-cleanup.C:
+			o.cleanup(ctx)
+		}
+	}
+}
+
+func (o *Outbox) dispatchPending(ctx context.Context) {
+	for ctx.Err() == nil && time.Now().After(o.retryAt) {
+		more, err := o.dispatch(ctx)
+		if err != nil {
+			log.Printf("outbox: %v", err)
+			return
+		}
+		if !more {
+			return
+		}
+	}
+}
+
+// dispatch hands one batch of pending events to the sinks, stopping at the
+// first event a sink fails so later events do not overtake it. It reports
+// whether the whole batch was handled and more may be waiting.
+func (o *Outbox) dispatch(ctx context.Context) (bool, error) {
+	var more bool
+	err := o.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		locked, err := o.repo.LockDispatch(ctx)
+		if err != nil || !locked {
+			return err
+		}
+
+		pending, err := o.repo.GetPending(ctx, outboxBatchSize)
+		if err != nil {
+			return err
+		}
+
+		dispatched := make([]uint64, 0, len(pending))
+		blocked := false
+		for i := range pending {
+			event := &pending[i]
+			// A savepoint per event undoes what the sinks wrote for one
+			// that fails, without losing the events before it
+			err := o.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+				return o.handle(ctx, event)
+			})
+			if err == nil {
+				dispatched = append(dispatched, event.ID)
+				continue
+			}
+
+			event.Attempts++
+			event.LastError = err.Error()
+			if event.Attempts >= outboxMaxAttempts {
+				now := time.Now()
+				event.FailedAt = &now
+				log.Printf("outbox: giving up on %s %s after %d attempts: %v", event.Name, event.EventID, event.Attempts, err)
+			} else {
+				o.retryAt = time.Now().Add(outboxBackoff(event.Attempts))
+				log.Printf("outbox: %s %s failed, retrying in %s: %v", event.Name, event.EventID, time.Until(o.retryAt).Round(time.Second), err)
+			}
+			if err := o.repo.RecordFailure(ctx, event); err != nil {
+				return err
+			}
+			if event.FailedAt == nil {
+				blocked = true
+				break
+			}
+		}
+
+		more = len(pending) == outboxBatchSize && !blocked
+		return o.repo.MarkDispatched(ctx, dispatched)
+	})
+	return more, err
+}
+
+func (o *Outbox) handle(ctx context.Context, row *entities.OutboxEvent) error {
+	event := events.Event{
+		ID:         row.EventID.String(),
+		Name:       row.Name,
+		Payload:    json.RawMessage(row.Payload),
+		OccurredAt: row.OccurredAt,
+	}
+	for _, sink := range o.sinks {
+		if err := sink.Handle(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Outbox) cleanup(ctx context.Context) {
+	deleted, err := o.repo.DeleteDispatched(ctx, time.Now().Add(-outboxRetention))
+	if err != nil {
+		log.Printf("outbox: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("outbox: deleted %d dispatched events", deleted)
+	}
+}
+
+// outboxBackoff doubles the wait after every failed attempt, starting at
+// outboxBaseBackoff and capped at outboxMaxBackoff.
+func outboxBackoff(attempts int) time.Duration {
+	if attempts >= 20 {
+		return outboxMaxBackoff
+	}
+	return min(outboxBaseBackoff<<(attempts-1), outboxMaxBackoff)
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	dto_base "product-manager/dto/base"
+	err_util "product-manager/utils/error"
+	"strconv"
+)
+
+func paginate(totalData int64, pagination *dto_base.PaginationRequest, basePath string) (*dto_base.PaginationMetadata, *dto_base.Link, error) {
+	totalPage := int(math.Ceil(float64(totalData) / float64(pagination.Limit)))
+	if pagination.Page > totalPage && totalPage != 0 {
+		return nil, nil, err_util.ErrPageNotFound
+	}
+
+	next := ""
+	prev := ""
+	if pagination.Page < totalPage {
+		next = fmt.Sprintf("%s%d", basePath, pagination.Page+1)
+	}
+	if pagination.Page > 1 {
+		prev = fmt.Sprintf("%s%d", basePath, pagination.Page-1)
+	}
+
+	return &dto_base.PaginationMetadata{
+		TotalData:   totalData,
+		TotalPage:   totalPage,
+		CurrentPage: pagination.Page,
+	}, &dto_base.Link{
+		Next: next,
+		Prev: prev,
+	}, nil
+}
+
+// cursorLink points at the page cursor starts, keeping every other parameter
+// of the request it came from.
+func cursorLink(query url.Values, cursor string, limit int) string {
+	params := url.Values{}
+	for key, values := range query {
+		params[key] = values
+	}
+	params.Del("page")
+	params.Set("cursor", cursor)
+	params.Set("limit", strconv.Itoa(limit))
+	return "/api/v1/products?" + params.Encode()
+}
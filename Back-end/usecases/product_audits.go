@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	dto_base "product-manager/dto/base"
+	dto "product-manager/dto/products"
+	"product-manager/entities"
+	"product-manager/repositories"
+	"product-manager/utils/token"
+	"reflect"
+)
+
+type ProductAuditUseCase interface {
+	GetHistory(ctx context.Context, productID uint, pagination *dto_base.PaginationRequest) (*dto.ProductAuditListResponse, error)
+	GetAll(ctx context.Context, pagination *dto_base.PaginationRequest, filter *dto.ProductAuditFilter) (*dto.ProductAuditListResponse, error)
+}
+
+type productAuditUseCase struct {
+	repo repositories.ProductAuditRepository
+}
+
+func NewProductAuditUseCase(repo repositories.ProductAuditRepository) ProductAuditUseCase {
+	return &productAuditUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *productAuditUseCase) GetHistory(ctx context.Context, productID uint, pagination *dto_base.PaginationRequest) (*dto.ProductAuditListResponse, error) {
+	audits, totalData, err := uc.repo.GetByProductID(ctx, productID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.buildListResponse(audits, totalData, pagination, fmt.Sprintf("/api/v1/products/%d/history?page=", productID))
+}
+
+func (uc *productAuditUseCase) GetAll(ctx context.Context, pagination *dto_base.PaginationRequest, filter *dto.ProductAuditFilter) (*dto.ProductAuditListResponse, error) {
+	audits, totalData, err := uc.repo.GetAll(ctx, pagination, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.buildListResponse(audits, totalData, pagination, "/api/v1/products/audits?page=")
+}
+
+func (uc *productAuditUseCase) buildListResponse(audits []entities.ProductAudit, totalData int64, pagination *dto_base.PaginationRequest, basePath string) (*dto.ProductAuditListResponse, error) {
+	meta, links, err := paginate(totalData, pagination, basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.ProductAuditResponse, len(audits))
+	for i, a := range audits {
+		changes := make(map[string]dto.FieldChange, len(a.Changes))
+		for field, change := range a.Changes {
+			changes[field] = dto.FieldChange{Before: change.Before, After: change.After}
+		}
+		res[i] = dto.ProductAuditResponse{
+			ID:            a.ID,
+			ProductID:     a.ProductID,
+			Action:        a.Action,
+			AdminID:       a.AdminID.String(),
+			AdminUsername: a.AdminUsername,
+			Changes:       changes,
+			CreatedAt:     a.CreatedAt,
+		}
+	}
+
+	return &dto.ProductAuditListResponse{
+		Data:       res,
+		Pagination: meta,
+		Links:      links,
+	}, nil
+}
+
+// newProductAudit builds an audit entry attributed to the admin on ctx.
+// before is nil for creations and after is nil for removals.
+func newProductAudit(ctx context.Context, action string, productID uint, before, after *entities.Product) *entities.ProductAudit {
+	audit := &entities.ProductAudit{
+		ProductID: productID,
+		Action:    action,
+		Changes:   diffProducts(before, after),
+	}
+	if claims := token.ClaimsFromContext(ctx); claims != nil {
+		audit.AdminID = claims.ID
+		audit.AdminUsername = claims.Username
+	}
+	return audit
+}
+
+func productSnapshot(p *entities.Product) map[string]any {
+	if p == nil {
+		return map[string]any{}
+	}
+	snapshot := map[string]any{
+		"name":        p.Name,
+		"category":    p.Category,
+		"category_id": nil,
+		"price":       p.Price,
+		"stock":       p.Stock,
+		"deleted_at":  nil,
+	}
+	if p.CategoryID != nil {
+		snapshot["category_id"] = *p.CategoryID
+	}
+	if p.TaxClassID != nil {
+		snapshot["tax_class_id"] = *p.TaxClassID
+	}
+	if p.ReorderPoint != nil {
+		snapshot["reorder_point"] = *p.ReorderPoint
+	}
+	if p.ReorderQuantity != nil {
+		snapshot["reorder_quantity"] = *p.ReorderQuantity
+	}
+	if p.DeletedAt.Valid {
+		snapshot["deleted_at"] = p.DeletedAt.Time
+	}
+	return snapshot
+}
+
+func diffProducts(before, after *entities.Product) entities.AuditChanges {
+	b := productSnapshot(before)
+	a := productSnapshot(after)
+
+	changes := entities.AuditChanges{}
+	for field, newValue := range a {
+		oldValue, ok := b[field]
+		if ok && reflect.DeepEqual(oldValue, newValue) || !ok && newValue == nil {
+			continue
+		}
+		changes[field] = entities.FieldChange{Before: oldValue, After: newValue}
+	}
+	for field, oldValue := range b {
+		if _, ok := a[field]; !ok && oldValue != nil {
+			changes[field] = entities.FieldChange{Before: oldValue, After: nil}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	dto "product-manager/dto/products"
+	err_util "product-manager/utils/error"
+)
+
+// Bulk runs mixed create/update/delete operations. Atomic mode wraps them in
+// one transaction and stops at the first failure; best-effort mode commits
+// every operation on its own.
+func (uc *productUseCase) Bulk(ctx context.Context, req *dto.BulkProductRequest) (*dto.BulkProductResponse, error) {
+	res := &dto.BulkProductResponse{
+		Mode:    req.Mode,
+		Results: make([]dto.BulkProductResult, len(req.Operations)),
+	}
+	for i, op := range req.Operations {
+		res.Results[i] = dto.BulkProductResult{Index: i, Op: op.Op, ID: op.ID, Status: dto.BulkStatusSkipped}
+	}
+
+	if req.Mode == dto.BulkModeBestEffort {
+		for i, op := range req.Operations {
+			uc.runBulkOperation(ctx, op, &res.Results[i])
+		}
+		res.Committed = true
+		tallyBulkResults(res)
+		return res, nil
+	}
+
+	err := uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		for i, op := range req.Operations {
+			if !uc.runBulkOperation(ctx, op, &res.Results[i]) {
+				return err_util.ErrBulkOperationRolledBack
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		for i := range res.Results {
+			if res.Results[i].Status == dto.BulkStatusSucceeded {
+				res.Results[i].Status = dto.BulkStatusRolledBack
+				res.Results[i].Data = nil
+			}
+		}
+	}
+	res.Committed = err == nil
+	tallyBulkResults(res)
+
+	return res, nil
+}
+
+func (uc *productUseCase) runBulkOperation(ctx context.Context, op dto.BulkProductOperation, result *dto.BulkProductResult) bool {
+	var (
+		product *dto.ProductResponse
+		err     error
+	)
+
+	switch op.Op {
+	case dto.BulkOpCreate:
+		product, err = uc.Create(ctx, op.Data)
+	case dto.BulkOpUpdate:
+		product, err = uc.Update(ctx, op.ID, op.Data, op.Version)
+	case dto.BulkOpDelete:
+		err = uc.Delete(ctx, op.ID, op.Version)
+	}
+
+	if err != nil {
+		result.Status = dto.BulkStatusFailed
+		result.Error = err.Error()
+		return false
+	}
+
+	result.Status = dto.BulkStatusSucceeded
+	result.Data = product
+	if product != nil {
+		result.ID = product.ID
+	}
+	return true
+}
+
+func tallyBulkResults(res *dto.BulkProductResponse) {
+	for _, r := range res.Results {
+		switch r.Status {
+		case dto.BulkStatusSucceeded:
+			res.Succeeded++
+		case dto.BulkStatusFailed:
+			res.Failed++
+		}
+	}
+}
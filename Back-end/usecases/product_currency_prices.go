@@ -0,0 +1,191 @@
+package usecases
+
+import (
+	"context"
+	"math/big"
+
+	dto "product-manager/dto/products"
+	"product-manager/entities"
+	"product-manager/repositories"
+	err_util "product-manager/utils/error"
+	"product-manager/utils/money"
+)
+
+type ProductCurrencyPriceUseCase interface {
+	GetByProductID(ctx context.Context, productID uint) ([]dto.CurrencyPriceResponse, error)
+	Set(ctx context.Context, productID uint, currency string, req *dto.CurrencyPriceRequest) (*dto.CurrencyPriceResponse, error)
+	Delete(ctx context.Context, productID uint, currency string) error
+}
+
+type productCurrencyPriceUseCase struct {
+	repo        repositories.ProductCurrencyPriceRepository
+	productRepo repositories.ProductRepository
+}
+
+func NewProductCurrencyPriceUseCase(repo repositories.ProductCurrencyPriceRepository, productRepo repositories.ProductRepository) ProductCurrencyPriceUseCase {
+	return &productCurrencyPriceUseCase{
+		repo:        repo,
+		productRepo: productRepo,
+	}
+}
+
+func (uc *productCurrencyPriceUseCase) GetByProductID(ctx context.Context, productID uint) ([]dto.CurrencyPriceResponse, error) {
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	prices, err := uc.repo.GetByProductIDs(ctx, []uint{productID}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.CurrencyPriceResponse, len(prices))
+	for i, p := range prices {
+		res[i] = *mapCurrencyPriceToResponse(&p)
+	}
+	return res, nil
+}
+
+func (uc *productCurrencyPriceUseCase) Set(ctx context.Context, productID uint, currency string, req *dto.CurrencyPriceRequest) (*dto.CurrencyPriceResponse, error) {
+	code, err := listCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	price := &entities.ProductCurrencyPrice{ProductID: productID, Price: money.New(req.Amount, code)}
+	if err := uc.repo.Save(ctx, price); err != nil {
+		return nil, err
+	}
+	return mapCurrencyPriceToResponse(price), nil
+}
+
+func (uc *productCurrencyPriceUseCase) Delete(ctx context.Context, productID uint, currency string) error {
+	code, err := listCurrency(currency)
+	if err != nil {
+		return err
+	}
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return err
+	}
+	return uc.repo.Delete(ctx, productID, code)
+}
+
+// listCurrency checks that a product can have its own price in currency,
+// which excludes the base currency the product price is already in.
+func listCurrency(currency string) (string, error) {
+	c, err := money.Lookup(currency)
+	if err != nil {
+		return "", err
+	}
+	if c.Code == entities.BaseCurrency {
+		return "", err_util.ErrBaseCurrencyPrice
+	}
+	return c.Code, nil
+}
+
+// inBaseCurrency takes an amount from a request as a catalog price or cost,
+// which are all in the base currency. An amount naming no currency is taken
+// to be in it.
+func inBaseCurrency(m money.Money) (money.Money, error) {
+	if m.Currency == "" {
+		return entities.BaseMoney(m.Amount), nil
+	}
+	c, err := money.Lookup(m.Currency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if c.Code != entities.BaseCurrency {
+		return money.Money{}, err_util.ErrNotBaseCurrency
+	}
+	return entities.BaseMoney(m.Amount), nil
+}
+
+// validateCurrency checks the currency a read asks prices in, if any.
+func validateCurrency(currency string) error {
+	if currency == "" {
+		return nil
+	}
+	_, err := money.Lookup(currency)
+	return err
+}
+
+// localize adds the price in currency to every product in res and their
+// variants, after promotions, so it agrees with the effective and sale
+// prices. A product's own price in that currency wins, with its promotions
+// taken off in the same proportion as from the base price; otherwise the
+// effective price is converted at the current exchange rate. Variants
+// without a price of their own show the product's.
+func (uc *productUseCase) localize(ctx context.Context, res []dto.ProductResponse, currency string) error {
+	if currency == "" || len(res) == 0 {
+		return nil
+	}
+	target, err := money.Lookup(currency)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uint, len(res))
+	for i, p := range res {
+		ids[i] = p.ID
+	}
+	listed, err := uc.listRepo.GetByProductIDs(ctx, ids, target.Code)
+	if err != nil {
+		return err
+	}
+	prices := make(map[uint]money.Money, len(listed))
+	for _, p := range listed {
+		prices[p.ProductID] = p.Price
+	}
+
+	// The rate is only looked up once a price needs converting
+	var rate *big.Rat
+	convert := func(base money.Money) (*dto.DisplayPrice, error) {
+		if target.Code == entities.BaseCurrency {
+			return &dto.DisplayPrice{Money: base, Formatted: base.String(), Source: dto.DisplayPriceBase}, nil
+		}
+		if rate == nil {
+			if rate, err = findRate(ctx, uc.rateRepo, entities.BaseCurrency, target.Code); err != nil {
+				return nil, err
+			}
+		}
+		converted, err := money.Convert(base, target.Code, rate)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.DisplayPrice{Money: converted, Formatted: converted.String(), Source: dto.DisplayPriceConverted, Rate: money.FormatRate(rate)}, nil
+	}
+
+	for i := range res {
+		p := &res[i]
+		if price, ok := prices[p.ID]; ok {
+			if p.EffectivePrice != p.BasePrice {
+				price.Amount = money.RoundHalfUp(new(big.Rat).SetFrac64(price.Amount*p.EffectivePrice.Amount, p.BasePrice.Amount))
+			}
+			p.DisplayPrice = &dto.DisplayPrice{Money: price, Formatted: price.String(), Source: dto.DisplayPriceList}
+		} else if p.DisplayPrice, err = convert(p.EffectivePrice); err != nil {
+			return err
+		}
+
+		for j := range p.Variants {
+			v := &p.Variants[j]
+			if v.Price == nil {
+				v.DisplayPrice = p.DisplayPrice
+			} else if v.DisplayPrice, err = convert(v.SalePrice); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func mapCurrencyPriceToResponse(p *entities.ProductCurrencyPrice) *dto.CurrencyPriceResponse {
+	return &dto.CurrencyPriceResponse{
+		ProductID: p.ProductID,
+		Price:     p.Price,
+		Formatted: p.Price.String(),
+		UpdatedAt: p.UpdatedAt,
+	}
+}
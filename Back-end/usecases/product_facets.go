@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	dto "product-manager/dto/products"
+	"product-manager/entities"
+	err_util "product-manager/utils/error"
+	"slices"
+)
+
+// priceFacetBuckets is roughly how many buckets the price histogram aims for
+const priceFacetBuckets = 10
+
+func validateFacets(facets []string) error {
+	for _, facet := range facets {
+		switch facet {
+		case dto.FacetCategory, dto.FacetPrice, dto.FacetStock:
+		default:
+			return fmt.Errorf("%w: %s", err_util.ErrInvalidFacet, facet)
+		}
+	}
+	return nil
+}
+
+// getFacets computes the requested facets over the filtered products, each
+// with its own filter lifted so multi-select choices keep their counts.
+func (uc *productUseCase) getFacets(ctx context.Context, filter *dto.ProductSearchFilter) (*dto.ProductFacets, error) {
+	if len(filter.Facets) == 0 {
+		return nil, nil
+	}
+
+	facets := &dto.ProductFacets{}
+	for _, facet := range filter.Facets {
+		var err error
+		switch facet {
+		case dto.FacetCategory:
+			f := *filter
+			f.CategoryIDs, f.CategorySlugs = nil, nil
+			f.Conditions = withoutConditions(f.Conditions, "category", "category_id")
+			facets.Categories, err = uc.categoryFacet(ctx, &f)
+		case dto.FacetPrice:
+			f := *filter
+			f.MinPrice, f.MaxPrice = nil, nil
+			f.Conditions = withoutConditions(f.Conditions, "price")
+			facets.Price, err = uc.priceFacet(ctx, &f)
+		case dto.FacetStock:
+			f := *filter
+			f.InStock = nil
+			f.Conditions = withoutConditions(f.Conditions, "stock", "total_stock")
+			facets.Stock, err = uc.stockFacet(ctx, &f)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return facets, nil
+}
+
+// withoutConditions drops the conditions on fields, leaving conditions
+// untouched since filter copies share it.
+func withoutConditions(conditions []dto.FilterCondition, fields ...string) []dto.FilterCondition {
+	var kept []dto.FilterCondition
+	for _, c := range conditions {
+		if !slices.Contains(fields, c.Field) {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+func (uc *productUseCase) categoryFacet(ctx context.Context, filter *dto.ProductSearchFilter) ([]dto.CategoryFacet, error) {
+	counts, err := uc.repo.CountByCategory(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.CategoryFacet, len(counts))
+	for i, c := range counts {
+		res[i] = dto.CategoryFacet{ID: c.CategoryID, Name: c.Name, Slug: c.Slug, Count: c.Count}
+	}
+	return res, nil
+}
+
+func (uc *productUseCase) priceFacet(ctx context.Context, filter *dto.ProductSearchFilter) (*dto.PriceFacet, error) {
+	priceRange, err := uc.repo.GetPriceRange(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if priceRange.Count == 0 {
+		return &dto.PriceFacet{Buckets: []dto.PriceBucket{}}, nil
+	}
+
+	width := bucketWidth(priceRange.Max - priceRange.Min)
+	counts, err := uc.repo.CountByPriceBucket(ctx, filter, width)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fill the gaps so the histogram has no holes between min and max
+	first, last := priceRange.Min/width, priceRange.Max/width
+	buckets := make([]dto.PriceBucket, 0, last-first+1)
+	for b := first; b <= last; b++ {
+		buckets = append(buckets, dto.PriceBucket{From: entities.BaseMoney(int64(b * width)), To: entities.BaseMoney(int64((b + 1) * width))})
+	}
+	for _, c := range counts {
+		buckets[uint(c.Bucket)-first].Count = c.Count
+	}
+
+	return &dto.PriceFacet{
+		Min:         entities.BaseMoney(int64(priceRange.Min)),
+		Max:         entities.BaseMoney(int64(priceRange.Max)),
+		BucketWidth: entities.BaseMoney(int64(width)),
+		Buckets:     buckets,
+	}, nil
+}
+
+// bucketWidth picks a round width, 1, 2 or 5 times a power of ten, that
+// splits span into about priceFacetBuckets buckets.
+func bucketWidth(span uint) uint {
+	target := (span + priceFacetBuckets - 1) / priceFacetBuckets
+	for magnitude := uint(1); ; magnitude *= 10 {
+		for _, step := range []uint{1, 2, 5} {
+			if step*magnitude >= target {
+				return step * magnitude
+			}
+		}
+	}
+}
+
+func (uc *productUseCase) stockFacet(ctx context.Context, filter *dto.ProductSearchFilter) (*dto.StockFacet, error) {
+	counts, err := uc.repo.CountByStock(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.StockFacet{InStock: counts.InStock, OutOfStock: counts.OutOfStock}, nil
+}
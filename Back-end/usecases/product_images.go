@@ -0,0 +1,284 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"net/http"
+
+	"product-manager/drivers/storage"
+	dto "product-manager/dto/products"
+	"product-manager/entities"
+	"product-manager/repositories"
+	err_util "product-manager/utils/error"
+	"product-manager/utils/imaging"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxImageSize   = 5 << 20
+	maxImagePixels = 40_000_000
+)
+
+// imageExtensions lists the accepted types by their sniffed MIME type.
+var imageExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+var thumbnailSizes = []struct {
+	name string
+	side int
+}{
+	{"small", 150},
+	{"medium", 400},
+	{"large", 800},
+}
+
+type ProductImageUseCase interface {
+	Upload(ctx context.Context, productID uint, r io.Reader, opts dto.ProductImageUploadOptions) (*dto.ProductImageResponse, error)
+	GetByProductID(ctx context.Context, productID uint) ([]dto.ProductImageResponse, error)
+	Update(ctx context.Context, productID, id uint, req *dto.ProductImageUpdateRequest) (*dto.ProductImageResponse, error)
+	Delete(ctx context.Context, productID, id uint) error
+}
+
+type productImageUseCase struct {
+	repo        repositories.ProductImageRepository
+	productRepo repositories.ProductRepository
+	storage     storage.Storage
+	txManager   repositories.TxManager
+}
+
+func NewProductImageUseCase(repo repositories.ProductImageRepository, productRepo repositories.ProductRepository, store storage.Storage, txManager repositories.TxManager) ProductImageUseCase {
+	return &productImageUseCase{
+		repo:        repo,
+		productRepo: productRepo,
+		storage:     store,
+		txManager:   txManager,
+	}
+}
+
+// Upload trusts only the file's content: the type is sniffed from its first
+// bytes and the image must decode. Files go to storage before the row is
+// written and are removed again if the row cannot be.
+func (uc *productImageUseCase) Upload(ctx context.Context, productID uint, r io.Reader, opts dto.ProductImageUploadOptions) (*dto.ProductImageResponse, error) {
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, err_util.ErrImageRequired
+	}
+	if len(data) > MaxImageSize {
+		return nil, err_util.ErrImageTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, err_util.ErrUnsupportedImageType
+	}
+
+	// Check the dimensions before decoding so a small file cannot expand
+	// into a huge bitmap
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err_util.ErrInvalidImage
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, err_util.ErrImageTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err_util.ErrInvalidImage
+	}
+
+	base := fmt.Sprintf("products/%d/%s", productID, uuid.NewString())
+	productImage := &entities.ProductImage{
+		ProductID:   productID,
+		Key:         base + "." + ext,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       config.Width,
+		Height:      config.Height,
+		Thumbnails:  entities.ImageThumbnails{},
+		IsPrimary:   opts.IsPrimary,
+	}
+
+	if err := uc.storage.Put(ctx, productImage.Key, bytes.NewReader(data), contentType); err != nil {
+		return nil, err
+	}
+	for _, size := range thumbnailSizes {
+		thumb, thumbType, thumbExt, err := encodeThumbnail(img, contentType, size.side)
+		if err != nil {
+			uc.removeFiles(ctx, productImage)
+			return nil, err
+		}
+		key := fmt.Sprintf("%s_%s.%s", base, size.name, thumbExt)
+		if err := uc.storage.Put(ctx, key, thumb, thumbType); err != nil {
+			uc.removeFiles(ctx, productImage)
+			return nil, err
+		}
+		productImage.Thumbnails[size.name] = key
+	}
+
+	position := -1
+	if opts.Position != nil {
+		position = *opts.Position
+	}
+
+	err = uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.productRepo.GetByIDForUpdate(ctx, productID); err != nil {
+			return err
+		}
+		return uc.repo.Create(ctx, productImage, position)
+	})
+	if err != nil {
+		uc.removeFiles(ctx, productImage)
+		return nil, err
+	}
+
+	return mapImageToResponse(productImage, uc.storage), nil
+}
+
+func (uc *productImageUseCase) GetByProductID(ctx context.Context, productID uint) ([]dto.ProductImageResponse, error) {
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	images, err := uc.repo.GetByProductIDs(ctx, []uint{productID})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.ProductImageResponse, len(images))
+	for i, img := range images {
+		res[i] = *mapImageToResponse(&img, uc.storage)
+	}
+	return res, nil
+}
+
+func (uc *productImageUseCase) Update(ctx context.Context, productID, id uint, req *dto.ProductImageUpdateRequest) (*dto.ProductImageResponse, error) {
+	var productImage *entities.ProductImage
+	err := uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.productRepo.GetByIDForUpdate(ctx, productID); err != nil {
+			return err
+		}
+
+		var err error
+		if productImage, err = uc.repo.GetByID(ctx, productID, id); err != nil {
+			return err
+		}
+
+		if req.Position != nil {
+			if err := uc.repo.Move(ctx, productImage, *req.Position); err != nil {
+				return err
+			}
+		}
+		// There is always one primary image, so it can only be replaced,
+		// never switched off
+		if req.IsPrimary != nil && *req.IsPrimary && !productImage.IsPrimary {
+			if err := uc.repo.SetPrimary(ctx, productID, id); err != nil {
+				return err
+			}
+		}
+
+		productImage, err = uc.repo.GetByID(ctx, productID, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapImageToResponse(productImage, uc.storage), nil
+}
+
+// Delete removes the files only after the row is gone for good, so a failed
+// transaction never leaves a row pointing at missing files.
+func (uc *productImageUseCase) Delete(ctx context.Context, productID, id uint) error {
+	var productImage *entities.ProductImage
+	err := uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.productRepo.GetByIDForUpdate(ctx, productID); err != nil {
+			return err
+		}
+
+		var err error
+		if productImage, err = uc.repo.GetByID(ctx, productID, id); err != nil {
+			return err
+		}
+		return uc.repo.Delete(ctx, productImage)
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.removeFiles(ctx, productImage)
+	return nil
+}
+
+func (uc *productImageUseCase) removeFiles(ctx context.Context, productImage *entities.ProductImage) {
+	removeImageFiles(ctx, uc.storage, []entities.ProductImage{*productImage})
+}
+
+// removeImageFiles deletes stored files on a best-effort basis; a leftover
+// file only wastes space, so failures are logged rather than returned.
+func removeImageFiles(ctx context.Context, store storage.Storage, images []entities.ProductImage) {
+	for _, img := range images {
+		for _, key := range img.Keys() {
+			if err := store.Delete(ctx, key); err != nil {
+				log.Printf("failed to remove image file %s: %v", key, err)
+			}
+		}
+	}
+}
+
+// encodeThumbnail keeps JPEGs as JPEG and turns PNGs and GIFs into PNG so
+// transparency survives.
+func encodeThumbnail(img image.Image, contentType string, side int) (io.Reader, string, string, error) {
+	thumb := imaging.Fit(img, side)
+
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, "", "", fmt.Errorf("failed to encode thumbnail: %w", err)
+		}
+		return &buf, "image/jpeg", "jpg", nil
+	}
+
+	if err := png.Encode(&buf, thumb); err != nil {
+		return nil, "", "", fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return &buf, "image/png", "png", nil
+}
+
+func mapImageToResponse(img *entities.ProductImage, store storage.Storage) *dto.ProductImageResponse {
+	thumbnails := make(map[string]string, len(img.Thumbnails))
+	for size, key := range img.Thumbnails {
+		thumbnails[size] = store.URL(key)
+	}
+
+	return &dto.ProductImageResponse{
+		ID:          img.ID,
+		URL:         store.URL(img.Key),
+		Thumbnails:  thumbnails,
+		ContentType: img.ContentType,
+		Size:        img.Size,
+		Width:       img.Width,
+		Height:      img.Height,
+		Position:    img.Position,
+		IsPrimary:   img.IsPrimary,
+		CreatedAt:   img.CreatedAt,
+	}
+}
@@ -0,0 +1,274 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	msg "product-manager/constant/messages"
+	dto "product-manager/dto/products"
+	"product-manager/entities"
+	err_util "product-manager/utils/error"
+	"product-manager/utils/money"
+)
+
+const (
+	importBatchSize = 500
+	importMaxErrors = 1000
+)
+
+var importRequiredColumns = []string{"name", "category", "price"}
+
+type importRow struct {
+	row      int
+	name     string
+	category string
+	price    money.Money
+	stock    *uint
+}
+
+// Import reads a CSV catalog in batches so only one batch is held in memory.
+// Invalid rows are reported and skipped; valid rows in a batch are written in
+// one transaction unless opts.DryRun is set.
+func (uc *productUseCase) Import(ctx context.Context, r io.Reader, opts dto.ProductImportOptions) (*dto.ProductImportReport, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, err_util.ErrImportFileRequired
+		}
+		return nil, fmt.Errorf("%w: %v", err_util.ErrImportInvalidCSV, err)
+	}
+
+	columns, err := mapImportColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.ProductImportReport{
+		DryRun: opts.DryRun,
+		Upsert: opts.Upsert,
+		Errors: []dto.ProductImportRowError{},
+	}
+	seen := make(map[string]int)
+	batch := make([]importRow, 0, importBatchSize)
+	rowNumber := 1
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		rowNumber++
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("%w: %v", err_util.ErrImportInvalidCSV, err)
+			}
+			report.TotalRows++
+			addImportError(report, rowNumber, "", []string{parseErr.Err.Error()})
+			continue
+		}
+		report.TotalRows++
+
+		row, problems := parseImportRow(rowNumber, record, columns)
+		if len(problems) == 0 {
+			key := strings.ToLower(row.name)
+			if first, ok := seen[key]; ok {
+				problems = append(problems, fmt.Sprintf("%s (first seen at row %d)", msg.IMPORT_DUPLICATE_NAME, first))
+			} else {
+				seen[key] = rowNumber
+			}
+		}
+		if len(problems) > 0 {
+			addImportError(report, rowNumber, row.name, problems)
+			continue
+		}
+
+		batch = append(batch, row)
+		if len(batch) == importBatchSize {
+			if err := uc.importBatch(ctx, batch, opts, report); err != nil {
+				return nil, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := uc.importBatch(ctx, batch, opts, report); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(report.Errors, func(i, j int) bool {
+		return report.Errors[i].Row < report.Errors[j].Row
+	})
+
+	return report, nil
+}
+
+func (uc *productUseCase) importBatch(ctx context.Context, batch []importRow, opts dto.ProductImportOptions, report *dto.ProductImportReport) error {
+	names := make([]string, len(batch))
+	for i, row := range batch {
+		names[i] = row.name
+	}
+
+	existing, err := uc.repo.FindByNames(ctx, names)
+	if err != nil {
+		return err
+	}
+	existingIDs := make(map[string]uint, len(existing))
+	for _, p := range existing {
+		existingIDs[strings.ToLower(p.Name)] = p.ID
+	}
+
+	// A dry run writes nothing, so it looks up each category the batch
+	// names once to catch the rows the import would turn away
+	categoryErrs := make(map[string]error)
+	checkCategory := func(name string) error {
+		slug := entities.Slugify(name)
+		if err, ok := categoryErrs[slug]; ok {
+			return err
+		}
+		_, err := uc.resolveCategory(ctx, nil, name)
+		categoryErrs[slug] = err
+		return err
+	}
+
+	write := func(ctx context.Context) error {
+		for _, row := range batch {
+			id, exists := existingIDs[strings.ToLower(row.name)]
+			if exists && !opts.Upsert {
+				addImportError(report, row.row, row.name, []string{msg.PRODUCT_ALREADY_EXISTS})
+				continue
+			}
+
+			if opts.DryRun {
+				if err := checkCategory(row.category); err != nil {
+					addImportError(report, row.row, row.name, []string{err.Error()})
+					continue
+				}
+				if exists {
+					report.Updated++
+				} else {
+					report.Created++
+				}
+				continue
+			}
+
+			var err error
+			if exists {
+				_, err = uc.Patch(ctx, id, &dto.ProductPatchRequest{
+					Name:     &row.name,
+					Category: &row.category,
+					Price:    &row.price,
+					Stock:    row.stock,
+				}, nil)
+			} else {
+				_, err = uc.Create(ctx, &dto.ProductRequest{
+					Name:     row.name,
+					Category: row.category,
+					Price:    row.price,
+					Stock:    row.stock,
+				})
+			}
+			if err != nil {
+				addImportError(report, row.row, row.name, []string{err.Error()})
+				continue
+			}
+
+			if exists {
+				report.Updated++
+			} else {
+				report.Created++
+			}
+		}
+		return nil
+	}
+
+	if opts.DryRun {
+		return write(ctx)
+	}
+	return uc.txManager.WithTransaction(ctx, write)
+}
+
+// mapImportColumns finds each known column in the header, ignoring case,
+// surrounding spaces and unknown columns.
+func mapImportColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+
+	for _, required := range importRequiredColumns {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: %s", err_util.ErrImportMissingColumn, required)
+		}
+	}
+
+	return columns, nil
+}
+
+func parseImportRow(rowNumber int, record []string, columns map[string]int) (importRow, []string) {
+	cell := func(column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	row := importRow{
+		row:      rowNumber,
+		name:     cell("name"),
+		category: cell("category"),
+	}
+
+	var problems []string
+	if row.name == "" {
+		problems = append(problems, msg.PRODUCT_NAME_REQUIRED)
+	}
+	if row.category == "" {
+		problems = append(problems, msg.PRODUCT_CATEGORY_REQUIRED)
+	}
+
+	if raw := cell("price"); raw == "" {
+		problems = append(problems, msg.PRODUCT_PRICE_REQUIRED)
+	} else if price, err := strconv.ParseUint(raw, 10, 64); err != nil || price == 0 {
+		problems = append(problems, msg.PRODUCT_PRICE_INVALID)
+	} else {
+		row.price = entities.BaseMoney(int64(price))
+	}
+
+	if raw := cell("stock"); raw != "" {
+		if stock, err := strconv.ParseUint(raw, 10, 64); err != nil {
+			problems = append(problems, msg.PRODUCT_STOCK_INVALID)
+		} else {
+			s := uint(stock)
+			row.stock = &s
+		}
+	}
+
+	return row, problems
+}
+
+func addImportError(report *dto.ProductImportReport, row int, name string, problems []string) {
+	report.Failed++
+	if len(report.Errors) >= importMaxErrors {
+		report.ErrorsTruncated = true
+		return
+	}
+	report.Errors = append(report.Errors, dto.ProductImportRowError{Row: row, Name: name, Errors: problems})
+}
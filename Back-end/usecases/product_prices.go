@@ -0,0 +1,358 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	dto "product-manager/dto/products"
+	"product-manager/entities"
+	"product-manager/repositories"
+	err_util "product-manager/utils/error"
+	"product-manager/utils/money"
+	"product-manager/utils/token"
+)
+
+const (
+	priceBatchSize  = 50
+	priceMaxWait    = time.Minute
+	priceRetryDelay = time.Second
+)
+
+type ProductPriceUseCase interface {
+	Schedule(ctx context.Context, productID uint, req *dto.ProductPriceRequest) (*dto.ProductPriceResponse, error)
+	GetByProductID(ctx context.Context, productID uint) ([]dto.ProductPriceResponse, error)
+	GetAt(ctx context.Context, productID uint, at time.Time) (*dto.ProductPriceResponse, error)
+	Cancel(ctx context.Context, productID, id uint) error
+}
+
+type productPriceUseCase struct {
+	repo        repositories.ProductPriceRepository
+	productRepo repositories.ProductRepository
+	txManager   repositories.TxManager
+	scheduler   *PriceScheduler
+}
+
+func NewProductPriceUseCase(repo repositories.ProductPriceRepository, productRepo repositories.ProductRepository, txManager repositories.TxManager, scheduler *PriceScheduler) ProductPriceUseCase {
+	return &productPriceUseCase{
+		repo:        repo,
+		productRepo: productRepo,
+		txManager:   txManager,
+		scheduler:   scheduler,
+	}
+}
+
+func (uc *productPriceUseCase) Schedule(ctx context.Context, productID uint, req *dto.ProductPriceRequest) (*dto.ProductPriceResponse, error) {
+	now := priceNow()
+	from := req.EffectiveFrom.Truncate(time.Microsecond)
+	if !from.After(now) {
+		return nil, err_util.ErrPriceStartNotInFuture
+	}
+	var to *time.Time
+	if req.EffectiveTo != nil {
+		t := req.EffectiveTo.Truncate(time.Microsecond)
+		if !t.After(from) {
+			return nil, err_util.ErrInvalidPricePeriod
+		}
+		to = &t
+	}
+
+	amount, err := inBaseCurrency(req.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	price := newProductPrice(ctx, productID, amount, from, to, req.Reason)
+	err = uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// The product lock serializes every change to its timeline
+		if _, err := uc.productRepo.GetByIDForUpdate(ctx, productID); err != nil {
+			return err
+		}
+		if err := setPrice(ctx, uc.repo, price); err != nil {
+			return err
+		}
+		uc.txManager.AfterCommit(ctx, uc.scheduler.Wake)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapPriceToResponse(price, now), nil
+}
+
+func (uc *productPriceUseCase) GetByProductID(ctx context.Context, productID uint) ([]dto.ProductPriceResponse, error) {
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	prices, err := uc.repo.GetByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := priceNow()
+	res := make([]dto.ProductPriceResponse, len(prices))
+	for i, p := range prices {
+		res[i] = *mapPriceToResponse(&p, now)
+	}
+	return res, nil
+}
+
+func (uc *productPriceUseCase) GetAt(ctx context.Context, productID uint, at time.Time) (*dto.ProductPriceResponse, error) {
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	price, err := uc.repo.GetAt(ctx, productID, at)
+	if err != nil {
+		return nil, err
+	}
+	return mapPriceToResponse(price, priceNow()), nil
+}
+
+// Cancel removes a price that has not taken effect yet. The price before it
+// runs on in its place, absorbing the one after it too when both are the
+// same, so cancelling a promotion leaves the timeline as it was.
+func (uc *productPriceUseCase) Cancel(ctx context.Context, productID, id uint) error {
+	return uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.productRepo.GetByIDForUpdate(ctx, productID); err != nil {
+			return err
+		}
+
+		price, err := uc.repo.GetByID(ctx, productID, id)
+		if err != nil {
+			return err
+		}
+		if !price.EffectiveFrom.After(priceNow()) {
+			return err_util.ErrPriceAlreadyEffective
+		}
+
+		timeline, err := uc.repo.GetByProductID(ctx, productID)
+		if err != nil {
+			return err
+		}
+		if err := uc.repo.Delete(ctx, price.ID); err != nil {
+			return err
+		}
+		uc.txManager.AfterCommit(ctx, uc.scheduler.Wake)
+
+		i := 0
+		for i < len(timeline) && timeline[i].ID != price.ID {
+			i++
+		}
+		if i == 0 || i == len(timeline) {
+			return nil
+		}
+
+		prev := &timeline[i-1]
+		prev.EffectiveTo = price.EffectiveTo
+		if i+1 < len(timeline) && timeline[i+1].Price == prev.Price {
+			next := &timeline[i+1]
+			if err := uc.repo.Delete(ctx, next.ID); err != nil {
+				return err
+			}
+			prev.EffectiveTo = next.EffectiveTo
+		}
+		return uc.repo.Update(ctx, prev)
+	})
+}
+
+// PriceScheduler copies prices onto their products as they take effect. It
+// sleeps until the next price is due, and Wake makes it look again after
+// the schedule changes.
+type PriceScheduler struct {
+	repo        repositories.ProductPriceRepository
+	productRepo repositories.ProductRepository
+	auditRepo   repositories.ProductAuditRepository
+	txManager   repositories.TxManager
+	outbox      *Outbox
+	wake        chan struct{}
+}
+
+func NewPriceScheduler(repo repositories.ProductPriceRepository, productRepo repositories.ProductRepository, auditRepo repositories.ProductAuditRepository, txManager repositories.TxManager, outbox *Outbox) *PriceScheduler {
+	return &PriceScheduler{
+		repo:        repo,
+		productRepo: productRepo,
+		auditRepo:   auditRepo,
+		txManager:   txManager,
+		outbox:      outbox,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Wake makes Run check the schedule now rather than when it next expects a
+// price to be due.
+func (s *PriceScheduler) Wake() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run applies prices as they fall due until ctx is done.
+func (s *PriceScheduler) Run(ctx context.Context) {
+	for {
+		s.applyDue(ctx)
+
+		wait := priceMaxWait
+		next, err := s.repo.NextDueAt(ctx)
+		if err != nil {
+			log.Printf("prices: %v", err)
+		} else if next != nil {
+			// A price still due after applyDue failed to apply, so it is
+			// retried after a pause rather than in a tight loop
+			wait = max(min(wait, time.Until(*next)), priceRetryDelay)
+		}
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		case <-s.wake:
+			timer.Stop()
+		}
+	}
+}
+
+func (s *PriceScheduler) applyDue(ctx context.Context) {
+	now := priceNow()
+	ids, err := s.repo.GetDueProductIDs(ctx, now, priceBatchSize)
+	if err != nil {
+		log.Printf("prices: %v", err)
+		return
+	}
+	for _, id := range ids {
+		if err := s.apply(ctx, id, now); err != nil {
+			log.Printf("prices: product %d: %v", id, err)
+		}
+	}
+}
+
+// apply sets the product to the price in effect at now, recording the change
+// in the audit log under the admin who scheduled it.
+func (s *PriceScheduler) apply(ctx context.Context, productID uint, now time.Time) error {
+	return s.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.productRepo.GetByIDForUpdate(ctx, productID)
+		if errors.Is(err, err_util.ErrProductNotFound) {
+			// A product in the trash keeps the price it was deleted with
+			return s.repo.MarkApplied(ctx, productID, now)
+		}
+		if err != nil {
+			return err
+		}
+
+		current, err := s.repo.GetAt(ctx, productID, now)
+		if err != nil {
+			return err
+		}
+		if current.Price != before.Price {
+			if err := s.productRepo.UpdatePrice(ctx, productID, current.Price); err != nil {
+				return err
+			}
+			after, err := s.productRepo.GetByID(ctx, productID)
+			if err != nil {
+				return err
+			}
+
+			audit := newProductAudit(ctx, entities.AuditActionUpdate, productID, before, after)
+			audit.AdminID = current.AdminID
+			audit.AdminUsername = current.AdminUsername
+			if err := s.auditRepo.Create(ctx, audit); err != nil {
+				return err
+			}
+			if err := s.outbox.Record(ctx, entities.EventProductUpdated, mapProductToResponse(after)); err != nil {
+				return err
+			}
+		}
+
+		return s.repo.MarkApplied(ctx, productID, now)
+	})
+}
+
+// setPrice writes price into the product's timeline over its period. Prices
+// it covers entirely are dropped and those it overlaps are cut short; one
+// running past its end resumes after it. A price without an end holds until
+// the next one scheduled after it starts, which is left in place.
+func setPrice(ctx context.Context, repo repositories.ProductPriceRepository, price *entities.ProductPrice) error {
+	if price.EffectiveTo == nil {
+		next, err := repo.GetNextStart(ctx, price.ProductID, price.EffectiveFrom)
+		if err != nil {
+			return err
+		}
+		price.EffectiveTo = next
+	}
+
+	from, to := price.EffectiveFrom, price.EffectiveTo
+	overlapping, err := repo.GetOverlapping(ctx, price.ProductID, from, to)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range overlapping {
+		end := p.EffectiveTo
+		if p.EffectiveFrom.Before(from) {
+			p.EffectiveTo = &from
+			if err := repo.Update(ctx, &p); err != nil {
+				return err
+			}
+		} else if err := repo.Delete(ctx, p.ID); err != nil {
+			return err
+		}
+
+		if to != nil && (end == nil || end.After(*to)) {
+			rest := p
+			rest.ID = 0
+			rest.EffectiveFrom = *to
+			rest.EffectiveTo = end
+			rest.AppliedAt = nil
+			rest.CreatedAt = time.Time{}
+			if err := repo.Create(ctx, &rest); err != nil {
+				return err
+			}
+		}
+	}
+
+	return repo.Create(ctx, price)
+}
+
+// newProductPrice builds a price attributed to the admin on ctx.
+func newProductPrice(ctx context.Context, productID uint, amount money.Money, from time.Time, to *time.Time, reason string) *entities.ProductPrice {
+	price := &entities.ProductPrice{
+		ProductID:     productID,
+		Price:         amount,
+		EffectiveFrom: from,
+		EffectiveTo:   to,
+		Reason:        reason,
+	}
+	if claims := token.ClaimsFromContext(ctx); claims != nil {
+		price.AdminID = claims.ID
+		price.AdminUsername = claims.Username
+	}
+	return price
+}
+
+// priceNow is the current time at the precision Postgres stores, so times
+// compared in Go match those read back.
+func priceNow() time.Time {
+	return time.Now().Truncate(time.Microsecond)
+}
+
+func mapPriceToResponse(p *entities.ProductPrice, now time.Time) *dto.ProductPriceResponse {
+	return &dto.ProductPriceResponse{
+		ID:            p.ID,
+		ProductID:     p.ProductID,
+		Price:         p.Price,
+		EffectiveFrom: p.EffectiveFrom,
+		EffectiveTo:   p.EffectiveTo,
+		Status:        p.Status(now),
+		Reason:        p.Reason,
+		AdminID:       p.AdminID.String(),
+		AdminUsername: p.AdminUsername,
+		AppliedAt:     p.AppliedAt,
+		CreatedAt:     p.CreatedAt,
+	}
+}
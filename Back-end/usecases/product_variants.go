@@ -0,0 +1,239 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	dto "product-manager/dto/products"
+	"product-manager/entities"
+	"product-manager/repositories"
+	"product-manager/utils/money"
+)
+
+type ProductVariantUseCase interface {
+	Create(ctx context.Context, productID uint, req *dto.ProductVariantRequest) (*dto.ProductVariantResponse, error)
+	GetByProductID(ctx context.Context, productID uint) ([]dto.ProductVariantResponse, error)
+	GetByID(ctx context.Context, productID, id uint) (*dto.ProductVariantResponse, error)
+	Update(ctx context.Context, productID, id uint, req *dto.ProductVariantRequest) (*dto.ProductVariantResponse, error)
+	Delete(ctx context.Context, productID, id uint) error
+}
+
+type productVariantUseCase struct {
+	repo         repositories.ProductVariantRepository
+	productRepo  repositories.ProductRepository
+	stockRepo    repositories.StockMovementRepository
+	layerRepo    repositories.CostLayerRepository
+	promoRepo    repositories.PromotionRepository
+	categoryRepo repositories.CategoryRepository
+	txManager    repositories.TxManager
+	outbox       *Outbox
+}
+
+func NewProductVariantUseCase(repo repositories.ProductVariantRepository, productRepo repositories.ProductRepository, stockRepo repositories.StockMovementRepository, layerRepo repositories.CostLayerRepository, promoRepo repositories.PromotionRepository, categoryRepo repositories.CategoryRepository, txManager repositories.TxManager, outbox *Outbox) ProductVariantUseCase {
+	return &productVariantUseCase{
+		repo:         repo,
+		productRepo:  productRepo,
+		stockRepo:    stockRepo,
+		layerRepo:    layerRepo,
+		promoRepo:    promoRepo,
+		categoryRepo: categoryRepo,
+		txManager:    txManager,
+		outbox:       outbox,
+	}
+}
+
+func (uc *productVariantUseCase) Create(ctx context.Context, productID uint, req *dto.ProductVariantRequest) (*dto.ProductVariantResponse, error) {
+	variant := &entities.ProductVariant{ProductID: productID}
+	if err := applyVariantRequest(variant, req); err != nil {
+		return nil, err
+	}
+	if err := variant.IsValid(); err != nil {
+		return nil, err
+	}
+
+	var product *entities.Product
+	err := uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		// Locking the parent serializes variant writes of one product, so
+		// two requests cannot both claim the same option set
+		var err error
+		if product, err = uc.productRepo.GetByIDForUpdate(ctx, productID); err != nil {
+			return err
+		}
+		return uc.watchStock(ctx, productID, func() error {
+			if err := uc.repo.Create(ctx, variant); err != nil {
+				return err
+			}
+			return uc.recordStockChange(ctx, product, variant.ID, 0, variant.Stock, stockReasonInitial)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.mapWithSalePrice(ctx, product, variant)
+}
+
+func (uc *productVariantUseCase) GetByProductID(ctx context.Context, productID uint) ([]dto.ProductVariantResponse, error) {
+	product, err := uc.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := uc.repo.GetByProductIDs(ctx, []uint{productID})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.ProductVariantResponse, len(variants))
+	for i, v := range variants {
+		res[i] = *mapVariantToResponse(&v, product.Price)
+	}
+	if err := uc.applyPromotions(ctx, product, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (uc *productVariantUseCase) GetByID(ctx context.Context, productID, id uint) (*dto.ProductVariantResponse, error) {
+	product, err := uc.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := uc.repo.GetByID(ctx, productID, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.mapWithSalePrice(ctx, product, variant)
+}
+
+func (uc *productVariantUseCase) Update(ctx context.Context, productID, id uint, req *dto.ProductVariantRequest) (*dto.ProductVariantResponse, error) {
+	var (
+		product *entities.Product
+		variant *entities.ProductVariant
+	)
+	err := uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if product, err = uc.productRepo.GetByIDForUpdate(ctx, productID); err != nil {
+			return err
+		}
+		if variant, err = uc.repo.GetByID(ctx, productID, id); err != nil {
+			return err
+		}
+
+		before := variant.Stock
+		if err := applyVariantRequest(variant, req); err != nil {
+			return err
+		}
+		if err := variant.IsValid(); err != nil {
+			return err
+		}
+		return uc.watchStock(ctx, productID, func() error {
+			if err := uc.repo.Update(ctx, variant); err != nil {
+				return err
+			}
+			return uc.recordStockChange(ctx, product, variant.ID, before, variant.Stock, stockReasonVariantUpdate)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.mapWithSalePrice(ctx, product, variant)
+}
+
+func (uc *productVariantUseCase) Delete(ctx context.Context, productID, id uint) error {
+	return uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		product, err := uc.productRepo.GetByIDForUpdate(ctx, productID)
+		if err != nil {
+			return err
+		}
+		variant, err := uc.repo.GetByID(ctx, productID, id)
+		if err != nil {
+			return err
+		}
+		return uc.watchStock(ctx, productID, func() error {
+			if err := uc.repo.Delete(ctx, productID, id); err != nil {
+				return err
+			}
+			// The stock goes with the variant, so the ledger lets it go too
+			return uc.recordStockChange(ctx, product, id, variant.Stock, 0, stockReasonVariantDelete)
+		})
+	})
+}
+
+// applyPromotions sets the sale price of the variants of product in res from
+// the promotions running now.
+func (uc *productVariantUseCase) applyPromotions(ctx context.Context, product *entities.Product, res []dto.ProductVariantResponse) error {
+	if len(res) == 0 {
+		return nil
+	}
+	engine, err := newPromotionEngine(ctx, uc.promoRepo, uc.categoryRepo, time.Now())
+	if err != nil {
+		return err
+	}
+	for i := range res {
+		res[i].SalePrice, _ = engine.Apply(res[i].EffectivePrice, product.ID, product.CategoryID)
+	}
+	return nil
+}
+
+func (uc *productVariantUseCase) mapWithSalePrice(ctx context.Context, product *entities.Product, variant *entities.ProductVariant) (*dto.ProductVariantResponse, error) {
+	res := []dto.ProductVariantResponse{*mapVariantToResponse(variant, product.Price)}
+	if err := uc.applyPromotions(ctx, product, res); err != nil {
+		return nil, err
+	}
+	return &res[0], nil
+}
+
+// recordStockChange puts stock written directly on a variant in the ledger,
+// taking stock added at the product's cost price.
+func (uc *productVariantUseCase) recordStockChange(ctx context.Context, product *entities.Product, variantID uint, before, after uint, reason string) error {
+	return recordStockChange(ctx, uc.stockRepo, uc.layerRepo, product.ID, &variantID, before, after, product.CostPrice, reason)
+}
+
+// watchStock runs write, which changes the variants and so the product's
+// total stock, under a stock level check.
+func (uc *productVariantUseCase) watchStock(ctx context.Context, productID uint, write func() error) error {
+	checkStockLevel, err := watchStockLevel(ctx, uc.productRepo, uc.outbox, productID)
+	if err != nil {
+		return err
+	}
+	if err := write(); err != nil {
+		return err
+	}
+	return checkStockLevel(ctx)
+}
+
+func applyVariantRequest(v *entities.ProductVariant, req *dto.ProductVariantRequest) error {
+	v.SKU = strings.TrimSpace(req.SKU)
+	v.Options = entities.VariantOptions(req.Options).Normalize()
+	v.OptionsKey = v.Options.Key()
+	v.Price = nil
+	if req.Price != nil {
+		price, err := inBaseCurrency(*req.Price)
+		if err != nil {
+			return err
+		}
+		v.Price = &price
+	}
+	v.Stock = derefUint(req.Stock)
+	return nil
+}
+
+func mapVariantToResponse(v *entities.ProductVariant, productPrice money.Money) *dto.ProductVariantResponse {
+	return &dto.ProductVariantResponse{
+		ID:             v.ID,
+		ProductID:      v.ProductID,
+		SKU:            v.SKU,
+		Options:        v.Options,
+		Price:          v.Price,
+		EffectivePrice: v.EffectivePrice(productPrice),
+		SalePrice:      v.EffectivePrice(productPrice),
+		Stock:          v.Stock,
+		InStock:        v.Stock > 0,
+		CreatedAt:      v.CreatedAt,
+		UpdatedAt:      v.UpdatedAt,
+	}
+}
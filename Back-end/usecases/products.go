@@ -2,126 +2,670 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math"
+	"html"
+	"io"
+	"product-manager/drivers/storage"
 	dto_base "product-manager/dto/base"
 	dto "product-manager/dto/products"
 	"product-manager/entities"
 	"product-manager/repositories"
 	err_util "product-manager/utils/error"
+	"product-manager/utils/money"
+	"product-manager/utils/token"
+	"strings"
+	"time"
 )
 
 type ProductUseCase interface {
 	Create(ctx context.Context, req *dto.ProductRequest) (*dto.ProductResponse, error)
-	GetByID(ctx context.Context, id uint) (*dto.ProductResponse, error)
+	GetByID(ctx context.Context, id uint, currency string) (*dto.ProductResponse, error)
 	GetAll(ctx context.Context, pagination *dto_base.PaginationRequest, filter *dto.ProductSearchFilter) (*dto.ProductListResponseWithLinks, error)
-	Update(ctx context.Context, id uint, req *dto.ProductRequest) (*dto.ProductResponse, error)
-	Delete(ctx context.Context, id uint) error
+	Update(ctx context.Context, id uint, req *dto.ProductRequest, expectedVersion *uint) (*dto.ProductResponse, error)
+	Patch(ctx context.Context, id uint, req *dto.ProductPatchRequest, expectedVersion *uint) (*dto.ProductResponse, error)
+	Delete(ctx context.Context, id uint, expectedVersion *uint) error
+	GetTrashed(ctx context.Context, pagination *dto_base.PaginationRequest) (*dto.ProductListResponseWithLinks, error)
+	Restore(ctx context.Context, id uint) (*dto.ProductResponse, error)
+	Purge(ctx context.Context, id uint) error
+	Bulk(ctx context.Context, req *dto.BulkProductRequest) (*dto.BulkProductResponse, error)
+	Import(ctx context.Context, r io.Reader, opts dto.ProductImportOptions) (*dto.ProductImportReport, error)
+	Export(ctx context.Context, sortBy string, filter *dto.ProductSearchFilter, fn func(product *dto.ProductResponse) error) error
 }
 
+const (
+	stockReasonInitial       = "initial stock"
+	stockReasonProductUpdate = "stock set through product update"
+	stockReasonVariantUpdate = "stock set through variant update"
+	stockReasonVariantDelete = "variant deleted"
+	priceReasonInitial       = "initial price"
+	priceReasonProductUpdate = "price set through product update"
+)
+
 type productUseCase struct {
-	repo repositories.ProductRepository
+	repo         repositories.ProductRepository
+	auditRepo    repositories.ProductAuditRepository
+	stockRepo    repositories.StockMovementRepository
+	layerRepo    repositories.CostLayerRepository
+	priceRepo    repositories.ProductPriceRepository
+	listRepo     repositories.ProductCurrencyPriceRepository
+	rateRepo     repositories.ExchangeRateRepository
+	promoRepo    repositories.PromotionRepository
+	taxRepo      repositories.TaxRepository
+	categoryRepo repositories.CategoryRepository
+	variantRepo  repositories.ProductVariantRepository
+	imageRepo    repositories.ProductImageRepository
+	storage      storage.Storage
+	txManager    repositories.TxManager
+	outbox       *Outbox
+	tax          TaxConfig
 }
 
-func NewProductUseCase(repo repositories.ProductRepository) ProductUseCase {
+func NewProductUseCase(repo repositories.ProductRepository, auditRepo repositories.ProductAuditRepository, stockRepo repositories.StockMovementRepository, layerRepo repositories.CostLayerRepository, priceRepo repositories.ProductPriceRepository, listRepo repositories.ProductCurrencyPriceRepository, rateRepo repositories.ExchangeRateRepository, promoRepo repositories.PromotionRepository, taxRepo repositories.TaxRepository, categoryRepo repositories.CategoryRepository, variantRepo repositories.ProductVariantRepository, imageRepo repositories.ProductImageRepository, store storage.Storage, txManager repositories.TxManager, outbox *Outbox, tax TaxConfig) ProductUseCase {
 	return &productUseCase{
-		repo: repo,
+		repo:         repo,
+		auditRepo:    auditRepo,
+		stockRepo:    stockRepo,
+		layerRepo:    layerRepo,
+		priceRepo:    priceRepo,
+		listRepo:     listRepo,
+		rateRepo:     rateRepo,
+		promoRepo:    promoRepo,
+		taxRepo:      taxRepo,
+		categoryRepo: categoryRepo,
+		variantRepo:  variantRepo,
+		imageRepo:    imageRepo,
+		storage:      store,
+		txManager:    txManager,
+		outbox:       outbox,
+		tax:          tax,
 	}
 }
 
 func (uc *productUseCase) Create(ctx context.Context, req *dto.ProductRequest) (*dto.ProductResponse, error) {
-
-
+	price, err := inBaseCurrency(req.Price)
+	if err != nil {
+		return nil, err
+	}
 	product := &entities.Product{
-		Name:     req.Name,
-		Category: req.Category,
-		Price:    req.Price,
-		Stock:    req.Stock,
+		Name:            req.Name,
+		Price:           price,
+		Stock:           derefUint(req.Stock),
+		TaxClassID:      req.TaxClassID,
+		ReorderPoint:    req.ReorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
 	}
 
-	if err := uc.repo.Create(ctx, product); err != nil {
+	err = uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		category, err := uc.resolveCategory(ctx, req.CategoryID, req.Category)
+		if err != nil {
+			return err
+		}
+		setCategory(product, category)
+		if err := uc.checkTaxClass(ctx, product.TaxClassID); err != nil {
+			return err
+		}
+
+		if err := uc.repo.Create(ctx, product); err != nil {
+			return err
+		}
+		if err := recordStockChange(ctx, uc.stockRepo, uc.layerRepo, product.ID, nil, 0, product.Stock, nil, stockReasonInitial); err != nil {
+			return err
+		}
+		if err := uc.recordPriceChange(ctx, product.ID, entities.BaseMoney(0), product.Price, priceReasonInitial); err != nil {
+			return err
+		}
+		if err := uc.outbox.Record(ctx, entities.EventProductCreated, mapProductToResponse(product)); err != nil {
+			return err
+		}
+		return uc.auditRepo.Create(ctx, newProductAudit(ctx, entities.AuditActionCreate, product.ID, nil, product))
+	})
+	if err != nil {
 		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
 
-	return uc.mapToResponse(product), nil
+	return mapProductToResponse(product), nil
 }
 
-func (uc *productUseCase) GetByID(ctx context.Context, id uint) (*dto.ProductResponse, error) {
+func (uc *productUseCase) GetByID(ctx context.Context, id uint, currency string) (*dto.ProductResponse, error) {
+	if err := validateCurrency(currency); err != nil {
+		return nil, err
+	}
+
 	product, err := uc.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return uc.mapToResponse(product), nil
+
+	res := []dto.ProductResponse{*mapProductToResponse(product)}
+	if err := uc.attachDetails(ctx, res); err != nil {
+		return nil, err
+	}
+	if err := uc.localize(ctx, res, currency); err != nil {
+		return nil, err
+	}
+	return &res[0], nil
 }
 
 func (uc *productUseCase) GetAll(ctx context.Context, pagination *dto_base.PaginationRequest, filter *dto.ProductSearchFilter) (*dto.ProductListResponseWithLinks, error) {
-	products, totalData, err := uc.repo.GetAll(ctx, pagination, filter)
+	if err := validateFacets(filter.Facets); err != nil {
+		return nil, err
+	}
+	if err := validateCurrency(filter.Currency); err != nil {
+		return nil, err
+	}
+
+	var res *dto.ProductListResponseWithLinks
+	if pagination.Cursor != nil {
+		var err error
+		if res, err = uc.getAllByCursor(ctx, pagination, filter); err != nil {
+			return nil, err
+		}
+	} else {
+		products, totalData, err := uc.repo.GetAll(ctx, pagination, filter)
+		if err != nil {
+			return nil, err
+		}
+		if res, err = uc.buildListResponse(ctx, products, totalData, pagination, "/api/v1/products?page="); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.localize(ctx, res.Data, filter.Currency); err != nil {
+		return nil, err
+	}
+
+	facets, err := uc.getFacets(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
+	res.Facets = facets
+	return res, nil
+}
 
-	totalPage := int(math.Ceil(float64(totalData) / float64(pagination.Limit)))
-	if pagination.Page > totalPage && totalPage != 0 {
-		return nil, err_util.ErrPageNotFound
+// getAllByCursor serves keyset pages. Counting every match would cost as
+// much as the OFFSET scan this mode avoids, so no pagination metadata is
+// returned.
+func (uc *productUseCase) getAllByCursor(ctx context.Context, pagination *dto_base.PaginationRequest, filter *dto.ProductSearchFilter) (*dto.ProductListResponseWithLinks, error) {
+	products, next, prev, err := uc.repo.GetAllByCursor(ctx, pagination, filter)
+	if err != nil {
+		return nil, err
 	}
 
 	res := make([]dto.ProductResponse, len(products))
 	for i, p := range products {
-		res[i] = *uc.mapToResponse(&p)
+		res[i] = *mapProductToResponse(&p)
+	}
+	if err := uc.attachDetails(ctx, res); err != nil {
+		return nil, err
 	}
 
-	basePath := "/api/v1/products?page="
-	next := ""
-	prev := ""
-	if pagination.Page < totalPage {
-		next = fmt.Sprintf("%s%d", basePath, pagination.Page+1)
+	links := &dto_base.Link{NextCursor: next, PrevCursor: prev}
+	if next != "" {
+		links.Next = cursorLink(pagination.Query, next, pagination.Limit)
 	}
-	if pagination.Page > 1 {
-		prev = fmt.Sprintf("%s%d", basePath, pagination.Page-1)
+	if prev != "" {
+		links.Prev = cursorLink(pagination.Query, prev, pagination.Limit)
 	}
 
 	return &dto.ProductListResponseWithLinks{
-		Data: res,
-		Pagination: &dto_base.PaginationMetadata{
-			TotalData:   totalData,
-			TotalPage:   totalPage,
-			CurrentPage: pagination.Page,
-		},
-		Links: &dto_base.Link{
-			Next: next,
-			Prev: prev,
-		},
+		Data:  res,
+		Links: links,
 	}, nil
 }
 
-func (uc *productUseCase) Update(ctx context.Context, id uint, req *dto.ProductRequest) (*dto.ProductResponse, error) {
+func (uc *productUseCase) Export(ctx context.Context, sortBy string, filter *dto.ProductSearchFilter, fn func(product *dto.ProductResponse) error) error {
+	return uc.repo.Stream(ctx, sortBy, filter, func(p *entities.Product) error {
+		return fn(mapProductToResponse(p))
+	})
+}
 
-	product := &entities.Product{
-		Name:     req.Name,
-		Category: req.Category,
-		Price:    req.Price,
-		Stock:    req.Stock,
+func (uc *productUseCase) Update(ctx context.Context, id uint, req *dto.ProductRequest, expectedVersion *uint) (*dto.ProductResponse, error) {
+	product, err := uc.update(ctx, id, expectedVersion, func(ctx context.Context, p *entities.Product) error {
+		price, err := inBaseCurrency(req.Price)
+		if err != nil {
+			return err
+		}
+		category, err := uc.resolveCategory(ctx, req.CategoryID, req.Category)
+		if err != nil {
+			return err
+		}
+		setCategory(p, category)
+
+		p.Name = req.Name
+		p.Price = price
+		p.Stock = derefUint(req.Stock)
+		p.TaxClassID = req.TaxClassID
+		p.ReorderPoint = req.ReorderPoint
+		p.ReorderQuantity = req.ReorderQuantity
+		return uc.checkTaxClass(ctx, p.TaxClassID)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := uc.repo.Update(ctx, id, product); err != nil {
+	return uc.mapWithDetails(ctx, product)
+}
+
+func (uc *productUseCase) Patch(ctx context.Context, id uint, req *dto.ProductPatchRequest, expectedVersion *uint) (*dto.ProductResponse, error) {
+	product, err := uc.update(ctx, id, expectedVersion, func(ctx context.Context, p *entities.Product) error {
+		if req.CategoryID != nil || req.Category != nil {
+			var name string
+			if req.Category != nil {
+				name = *req.Category
+			}
+			category, err := uc.resolveCategory(ctx, req.CategoryID, name)
+			if err != nil {
+				return err
+			}
+			setCategory(p, category)
+		}
+		if req.Name != nil {
+			p.Name = *req.Name
+		}
+		if req.Price != nil {
+			price, err := inBaseCurrency(*req.Price)
+			if err != nil {
+				return err
+			}
+			p.Price = price
+		}
+		if req.Stock != nil {
+			p.Stock = *req.Stock
+		}
+		if req.TaxClassID != nil {
+			p.TaxClassID = *req.TaxClassID
+			if err := uc.checkTaxClass(ctx, p.TaxClassID); err != nil {
+				return err
+			}
+		}
+		if req.ReorderPoint != nil {
+			p.ReorderPoint = *req.ReorderPoint
+		}
+		if req.ReorderQuantity != nil {
+			p.ReorderQuantity = *req.ReorderQuantity
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.mapWithDetails(ctx, product)
+}
+
+// update applies changes to the locked product row and returns the record as
+// persisted, so callers never echo back their own request. A non-nil
+// expectedVersion must match the stored version.
+func (uc *productUseCase) update(ctx context.Context, id uint, expectedVersion *uint, apply func(ctx context.Context, p *entities.Product) error) (*entities.Product, error) {
+	var updated *entities.Product
+	err := uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		before, err := uc.repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if expectedVersion != nil && *expectedVersion != before.Version {
+			return err_util.ErrProductVersionConflict
+		}
+
+		checkStockLevel, err := watchStockLevel(ctx, uc.repo, uc.outbox, id)
+		if err != nil {
+			return err
+		}
+
+		product := *before
+		if err := apply(ctx, &product); err != nil {
+			return err
+		}
+
+		if err := uc.repo.Update(ctx, id, &product); err != nil {
+			return err
+		}
+		if err := checkStockLevel(ctx); err != nil {
+			return err
+		}
+
+		if err := recordStockChange(ctx, uc.stockRepo, uc.layerRepo, id, nil, before.Stock, product.Stock, before.CostPrice, stockReasonProductUpdate); err != nil {
+			return err
+		}
+		if err := uc.recordPriceChange(ctx, id, before.Price, product.Price, priceReasonProductUpdate); err != nil {
+			return err
+		}
+
+		updated, err = uc.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := uc.outbox.Record(ctx, entities.EventProductUpdated, mapProductToResponse(updated)); err != nil {
+			return err
+		}
+
+		return uc.auditRepo.Create(ctx, newProductAudit(ctx, entities.AuditActionUpdate, id, before, updated))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (uc *productUseCase) Delete(ctx context.Context, id uint, expectedVersion *uint) error {
+	return uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		before, err := uc.repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if expectedVersion != nil && *expectedVersion != before.Version {
+			return err_util.ErrProductVersionConflict
+		}
+
+		if err := uc.repo.Delete(ctx, id, before.Version); err != nil {
+			return err
+		}
+		if err := uc.outbox.Record(ctx, entities.EventProductDeleted, mapProductToResponse(before)); err != nil {
+			return err
+		}
+
+		return uc.auditRepo.Create(ctx, newProductAudit(ctx, entities.AuditActionDelete, id, before, nil))
+	})
+}
+
+func (uc *productUseCase) GetTrashed(ctx context.Context, pagination *dto_base.PaginationRequest) (*dto.ProductListResponseWithLinks, error) {
+	products, totalData, err := uc.repo.GetTrashed(ctx, pagination)
+	if err != nil {
 		return nil, err
 	}
 
-	return uc.mapToResponse(product), nil
+	return uc.buildListResponse(ctx, products, totalData, pagination, "/api/v1/products/trash?page=")
 }
 
-func (uc *productUseCase) Delete(ctx context.Context, id uint) error {
-	return uc.repo.Delete(ctx, id)
+func (uc *productUseCase) Restore(ctx context.Context, id uint) (*dto.ProductResponse, error) {
+	var restored *entities.Product
+	err := uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		before, err := uc.repo.GetTrashedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.repo.Restore(ctx, id); err != nil {
+			return err
+		}
+
+		restored, err = uc.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := uc.outbox.Record(ctx, entities.EventProductUpdated, mapProductToResponse(restored)); err != nil {
+			return err
+		}
+
+		return uc.auditRepo.Create(ctx, newProductAudit(ctx, entities.AuditActionRestore, id, before, restored))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.mapWithDetails(ctx, restored)
+}
+
+// Purge drops the product's image rows through the foreign key cascade and
+// removes their files once the purge has committed.
+func (uc *productUseCase) Purge(ctx context.Context, id uint) error {
+	var images []entities.ProductImage
+	err := uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		before, err := uc.repo.GetTrashedByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if images, err = uc.imageRepo.GetByProductIDs(ctx, []uint{id}); err != nil {
+			return err
+		}
+
+		if err := uc.repo.Purge(ctx, id); err != nil {
+			return err
+		}
+
+		return uc.auditRepo.Create(ctx, newProductAudit(ctx, entities.AuditActionPurge, id, before, nil))
+	})
+	if err != nil {
+		return err
+	}
+
+	removeImageFiles(ctx, uc.storage, images)
+	return nil
+}
+
+// resolveCategory finds the category a request names, preferring the explicit
+// ID over the free-text name, which is matched by its slug.
+func (uc *productUseCase) resolveCategory(ctx context.Context, id *uint, name string) (*entities.Category, error) {
+	if id != nil {
+		return uc.categoryRepo.GetByID(ctx, *id)
+	}
+	slug := entities.Slugify(name)
+	if slug == "" {
+		return nil, err_util.ErrProductCategoryRequired
+	}
+	return uc.categoryRepo.GetBySlug(ctx, slug)
+}
+
+// checkTaxClass makes sure the tax class a product is put in exists.
+func (uc *productUseCase) checkTaxClass(ctx context.Context, id *uint) error {
+	if id == nil {
+		return nil
+	}
+	_, err := uc.taxRepo.GetByID(ctx, *id)
+	return err
+}
+
+// setCategory points p at category and refreshes the cached category name.
+func setCategory(p *entities.Product, category *entities.Category) {
+	p.CategoryID = &category.ID
+	p.Category = category.Name
+}
+
+// recordStockChange keeps the stock ledger and cost layers in step with
+// stock written directly on a product row, or on a variant row when
+// variantID is set. Stock added this way is taken at cost, the product's
+// cost price.
+func recordStockChange(ctx context.Context, stockRepo repositories.StockMovementRepository, layerRepo repositories.CostLayerRepository, productID uint, variantID *uint, before, after uint, cost *money.Money, reason string) error {
+	if before == after {
+		return nil
+	}
+	delta := int(after) - int(before)
+	movement := newStockMovement(ctx, productID, variantID, entities.StockMovementAdjustment, delta, after, reason, "")
+	if err := stockRepo.Create(ctx, movement); err != nil {
+		return err
+	}
+	return recordCostLayer(ctx, layerRepo, movement, cost)
+}
+
+// recordPriceChange keeps the price history in step with a price written
+// directly on the product row. The new price holds until the next scheduled
+// change, which stays in place.
+func (uc *productUseCase) recordPriceChange(ctx context.Context, productID uint, before, after money.Money, reason string) error {
+	if before == after {
+		return nil
+	}
+
+	now := priceNow()
+	var to *time.Time
+	current, err := uc.priceRepo.GetAt(ctx, productID, now)
+	if err == nil {
+		to = current.EffectiveTo
+	} else if !errors.Is(err, err_util.ErrPriceNotFound) {
+		return err
+	}
+
+	price := newProductPrice(ctx, productID, after, now, to, reason)
+	price.AppliedAt = &now
+	return setPrice(ctx, uc.priceRepo, price)
+}
+
+func (uc *productUseCase) buildListResponse(ctx context.Context, products []entities.Product, totalData int64, pagination *dto_base.PaginationRequest, basePath string) (*dto.ProductListResponseWithLinks, error) {
+	meta, links, err := paginate(totalData, pagination, basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.ProductResponse, len(products))
+	for i, p := range products {
+		res[i] = *mapProductToResponse(&p)
+	}
+	if err := uc.attachDetails(ctx, res); err != nil {
+		return nil, err
+	}
+
+	return &dto.ProductListResponseWithLinks{
+		Data:       res,
+		Pagination: meta,
+		Links:      links,
+	}, nil
+}
+
+func (uc *productUseCase) mapWithDetails(ctx context.Context, p *entities.Product) (*dto.ProductResponse, error) {
+	res := []dto.ProductResponse{*mapProductToResponse(p)}
+	if err := uc.attachDetails(ctx, res); err != nil {
+		return nil, err
+	}
+	return &res[0], nil
+}
+
+func (uc *productUseCase) attachDetails(ctx context.Context, res []dto.ProductResponse) error {
+	if err := uc.attachVariants(ctx, res); err != nil {
+		return err
+	}
+	if err := uc.applyPromotions(ctx, res); err != nil {
+		return err
+	}
+	if err := uc.applyTaxes(ctx, res); err != nil {
+		return err
+	}
+	if err := uc.attachCosts(ctx, res); err != nil {
+		return err
+	}
+	return uc.attachImages(ctx, res)
+}
+
+// attachCosts fills in cost prices for callers with the finance permission.
+func (uc *productUseCase) attachCosts(ctx context.Context, res []dto.ProductResponse) error {
+	if len(res) == 0 || !token.HasPermission(ctx, token.PermissionFinance) {
+		return nil
+	}
+
+	ids := make([]uint, len(res))
+	for i, p := range res {
+		ids[i] = p.ID
+	}
+	costs, err := uc.repo.GetCostPrices(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range res {
+		if cost, ok := costs[res[i].ID]; ok {
+			res[i].CostPrice = &cost
+		}
+	}
+	return nil
+}
+
+// attachVariants loads the variants of every product in res with a single
+// query and replaces the stock-based availability of those that have any.
+func (uc *productUseCase) attachVariants(ctx context.Context, res []dto.ProductResponse) error {
+	ids := make([]uint, len(res))
+	index := make(map[uint]int, len(res))
+	for i, p := range res {
+		ids[i] = p.ID
+		index[p.ID] = i
+	}
+
+	variants, err := uc.variantRepo.GetByProductIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range variants {
+		p := &res[index[v.ProductID]]
+		if p.Availability.VariantCount == 0 {
+			p.Availability.TotalStock = 0
+		}
+		p.Variants = append(p.Variants, *mapVariantToResponse(&v, p.Price))
+		p.Availability.TotalStock += v.Stock
+		p.Availability.VariantCount++
+		p.Availability.InStock = p.Availability.TotalStock > 0
+	}
+	return nil
+}
+
+func (uc *productUseCase) attachImages(ctx context.Context, res []dto.ProductResponse) error {
+	ids := make([]uint, len(res))
+	index := make(map[uint]int, len(res))
+	for i, p := range res {
+		ids[i] = p.ID
+		index[p.ID] = i
+	}
+
+	images, err := uc.imageRepo.GetByProductIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, img := range images {
+		p := &res[index[img.ProductID]]
+		p.Images = append(p.Images, *mapImageToResponse(&img, uc.storage))
+	}
+	return nil
+}
+
+func mapProductToResponse(p *entities.Product) *dto.ProductResponse {
+	res := &dto.ProductResponse{
+		ID:              p.ID,
+		Name:            p.Name,
+		Category:        p.Category,
+		CategoryID:      p.CategoryID,
+		Price:           p.Price,
+		BasePrice:       p.Price,
+		EffectivePrice:  p.Price,
+		Stock:           p.Stock,
+		Availability:    &dto.ProductAvailability{TotalStock: p.Stock, InStock: p.Stock > 0},
+		TaxClassID:      p.TaxClassID,
+		ReorderPoint:    p.ReorderPoint,
+		ReorderQuantity: p.ReorderQuantity,
+		Version:         p.Version,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+	if p.DeletedAt.Valid {
+		deletedAt := p.DeletedAt.Time
+		res.DeletedAt = &deletedAt
+	}
+	if p.SearchRank != nil {
+		res.Relevance = p.SearchRank
+		res.Highlights = &dto.ProductHighlights{
+			Name:     highlightToHTML(p.NameHighlight),
+			Category: highlightToHTML(p.CategoryHighlight),
+		}
+	}
+	return res
+}
+
+// highlightToHTML escapes highlighted text and turns the match marks into
+// <mark> elements.
+func highlightToHTML(text *string) string {
+	if text == nil {
+		return ""
+	}
+	escaped := html.EscapeString(*text)
+	return strings.NewReplacer(
+		entities.SearchHighlightStart, "<mark>",
+		entities.SearchHighlightStop, "</mark>",
+	).Replace(escaped)
 }
 
-func (uc *productUseCase) mapToResponse(p *entities.Product) *dto.ProductResponse {
-	return &dto.ProductResponse{
-		ID:        p.ID,
-		Name:      p.Name,
-		Category:  p.Category,
-		Price:     p.Price,
-		Stock:     p.Stock,
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
+func derefUint(v *uint) uint {
+	if v == nil {
+		return 0
 	}
+	return *v
 }
@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	dto "product-manager/dto/products"
+	"product-manager/entities"
+	"product-manager/repositories"
+	"product-manager/utils/money"
+)
+
+// promotionEngine takes the promotions running at one point in time off
+// product prices.
+type promotionEngine struct {
+	promotions []entities.Promotion
+	// categoryPaths is only loaded when a promotion is scoped to categories
+	categoryPaths map[uint]string
+}
+
+func newPromotionEngine(ctx context.Context, promoRepo repositories.PromotionRepository, categoryRepo repositories.CategoryRepository, at time.Time) (*promotionEngine, error) {
+	promotions, err := promoRepo.GetActive(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	engine := &promotionEngine{promotions: promotions}
+	for _, p := range promotions {
+		if p.Scope != entities.PromotionScopeCategories {
+			continue
+		}
+		categories, err := categoryRepo.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		engine.categoryPaths = make(map[uint]string, len(categories))
+		for _, c := range categories {
+			engine.categoryPaths[c.ID] = c.Path
+		}
+		break
+	}
+	return engine, nil
+}
+
+// Apply returns price less the promotions covering the product, along with
+// those promotions. Promotions are tried highest priority first: an
+// exclusive one applies on its own if nothing has applied before it and is
+// skipped otherwise, and each stackable one comes off what the ones before
+// it left.
+func (e *promotionEngine) Apply(price money.Money, productID uint, categoryID *uint) (money.Money, []dto.AppliedPromotion) {
+	var applied []dto.AppliedPromotion
+	for i := range e.promotions {
+		p := &e.promotions[i]
+		if !e.covers(p, productID, categoryID) {
+			continue
+		}
+		if p.Stacking == entities.PromotionExclusive && len(applied) > 0 {
+			continue
+		}
+
+		discount := p.Discount(price)
+		price = price.Sub(discount)
+		applied = append(applied, dto.AppliedPromotion{
+			ID:       p.ID,
+			Name:     p.Name,
+			Type:     p.Type,
+			Value:    p.Value,
+			Amount:   p.Amount,
+			Discount: discount,
+		})
+		if p.Stacking == entities.PromotionExclusive {
+			break
+		}
+	}
+	return price, applied
+}
+
+func (e *promotionEngine) covers(p *entities.Promotion, productID uint, categoryID *uint) bool {
+	switch p.Scope {
+	case entities.PromotionScopeAll:
+		return true
+	case entities.PromotionScopeProducts:
+		return slices.Contains(p.TargetIDs, productID)
+	case entities.PromotionScopeCategories:
+		if categoryID == nil {
+			return false
+		}
+		path, ok := e.categoryPaths[*categoryID]
+		if !ok {
+			return false
+		}
+		for _, id := range p.TargetIDs {
+			if target, ok := e.categoryPaths[id]; ok && target != "" && strings.HasPrefix(path, target) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyPromotions sets the effective price of every product in res and the
+// sale price of their variants from the promotions running now.
+func (uc *productUseCase) applyPromotions(ctx context.Context, res []dto.ProductResponse) error {
+	if len(res) == 0 {
+		return nil
+	}
+	engine, err := newPromotionEngine(ctx, uc.promoRepo, uc.categoryRepo, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for i := range res {
+		p := &res[i]
+		p.EffectivePrice, p.Promotions = engine.Apply(p.BasePrice, p.ID, p.CategoryID)
+		for j := range p.Variants {
+			v := &p.Variants[j]
+			v.SalePrice, _ = engine.Apply(v.EffectivePrice, p.ID, p.CategoryID)
+		}
+	}
+	return nil
+}
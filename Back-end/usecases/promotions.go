@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	dto "product-manager/dto/promotions"
+	"product-manager/entities"
+	"product-manager/repositories"
+	err_util "product-manager/utils/error"
+)
+
+type PromotionUseCase interface {
+	Create(ctx context.Context, req *dto.PromotionRequest) (*dto.PromotionResponse, error)
+	GetAll(ctx context.Context, status string) ([]dto.PromotionResponse, error)
+	GetByID(ctx context.Context, id uint) (*dto.PromotionResponse, error)
+	Update(ctx context.Context, id uint, req *dto.PromotionRequest) (*dto.PromotionResponse, error)
+	Delete(ctx context.Context, id uint) error
+}
+
+type promotionUseCase struct {
+	repo         repositories.PromotionRepository
+	productRepo  repositories.ProductRepository
+	categoryRepo repositories.CategoryRepository
+}
+
+func NewPromotionUseCase(repo repositories.PromotionRepository, productRepo repositories.ProductRepository, categoryRepo repositories.CategoryRepository) PromotionUseCase {
+	return &promotionUseCase{
+		repo:         repo,
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (uc *promotionUseCase) Create(ctx context.Context, req *dto.PromotionRequest) (*dto.PromotionResponse, error) {
+	promotion := &entities.Promotion{}
+	if err := uc.applyRequest(ctx, promotion, req); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Create(ctx, promotion); err != nil {
+		return nil, err
+	}
+	return mapPromotionToResponse(promotion, time.Now()), nil
+}
+
+func (uc *promotionUseCase) GetAll(ctx context.Context, status string) ([]dto.PromotionResponse, error) {
+	switch status {
+	case "", entities.PromotionStatusScheduled, entities.PromotionStatusActive, entities.PromotionStatusEnded:
+	default:
+		return nil, err_util.ErrInvalidPromotionStatus
+	}
+
+	now := time.Now()
+	promotions, err := uc.repo.GetAll(ctx, status, now)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.PromotionResponse, len(promotions))
+	for i, p := range promotions {
+		res[i] = *mapPromotionToResponse(&p, now)
+	}
+	return res, nil
+}
+
+func (uc *promotionUseCase) GetByID(ctx context.Context, id uint) (*dto.PromotionResponse, error) {
+	promotion, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapPromotionToResponse(promotion, time.Now()), nil
+}
+
+func (uc *promotionUseCase) Update(ctx context.Context, id uint, req *dto.PromotionRequest) (*dto.PromotionResponse, error) {
+	promotion, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.applyRequest(ctx, promotion, req); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Update(ctx, promotion); err != nil {
+		return nil, err
+	}
+	return mapPromotionToResponse(promotion, time.Now()), nil
+}
+
+func (uc *promotionUseCase) Delete(ctx context.Context, id uint) error {
+	return uc.repo.Delete(ctx, id)
+}
+
+// applyRequest copies req onto promotion and checks that the products or
+// categories it targets exist.
+func (uc *promotionUseCase) applyRequest(ctx context.Context, promotion *entities.Promotion, req *dto.PromotionRequest) error {
+	targets := slices.Clone(req.TargetIDs)
+	slices.Sort(targets)
+
+	promotion.Name = strings.TrimSpace(req.Name)
+	promotion.Description = strings.TrimSpace(req.Description)
+	promotion.Type = req.Type
+	promotion.Value = req.Value
+	promotion.Amount = nil
+	if req.Amount != nil {
+		amount, err := inBaseCurrency(*req.Amount)
+		if err != nil {
+			return err
+		}
+		promotion.Amount = &amount
+	}
+	promotion.Scope = req.Scope
+	promotion.TargetIDs = slices.Compact(targets)
+	promotion.Priority = req.Priority
+	promotion.Stacking = req.Stacking
+	if promotion.Stacking == "" {
+		promotion.Stacking = entities.PromotionStackable
+	}
+	promotion.StartsAt = *req.StartsAt
+	promotion.EndsAt = *req.EndsAt
+	if err := promotion.IsValid(); err != nil {
+		return err
+	}
+
+	for _, id := range promotion.TargetIDs {
+		var err error
+		if promotion.Scope == entities.PromotionScopeProducts {
+			_, err = uc.productRepo.GetByID(ctx, id)
+		} else {
+			_, err = uc.categoryRepo.GetByID(ctx, id)
+		}
+		if errors.Is(err, err_util.ErrProductNotFound) || errors.Is(err, err_util.ErrCategoryNotFound) {
+			return fmt.Errorf("%w: %d", err, id)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mapPromotionToResponse(p *entities.Promotion, now time.Time) *dto.PromotionResponse {
+	return &dto.PromotionResponse{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Type:        p.Type,
+		Value:       p.Value,
+		Amount:      p.Amount,
+		Scope:       p.Scope,
+		TargetIDs:   p.TargetIDs,
+		Priority:    p.Priority,
+		Stacking:    p.Stacking,
+		StartsAt:    p.StartsAt,
+		EndsAt:      p.EndsAt,
+		Status:      p.Status(now),
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
@@ -0,0 +1,297 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	dto_base "product-manager/dto/base"
+	dto "product-manager/dto/stocks"
+	"product-manager/entities"
+	"product-manager/repositories"
+	err_util "product-manager/utils/error"
+	"product-manager/utils/money"
+	"product-manager/utils/token"
+)
+
+type StockMovementUseCase interface {
+	Record(ctx context.Context, productID uint, req *dto.StockMovementRequest) (*dto.StockMovementResponse, error)
+	GetByProductID(ctx context.Context, productID uint, pagination *dto_base.PaginationRequest) (*dto.StockMovementListResponse, error)
+	Reconcile(ctx context.Context, productID uint) (*dto.StockReconciliationResponse, error)
+	GetDiscrepancies(ctx context.Context) ([]dto.StockReconciliationResponse, error)
+	GetLowStock(ctx context.Context, pagination *dto_base.PaginationRequest) (*dto.LowStockListResponse, error)
+}
+
+type stockMovementUseCase struct {
+	repo        repositories.StockMovementRepository
+	productRepo repositories.ProductRepository
+	variantRepo repositories.ProductVariantRepository
+	layerRepo   repositories.CostLayerRepository
+	txManager   repositories.TxManager
+	outbox      *Outbox
+}
+
+func NewStockMovementUseCase(repo repositories.StockMovementRepository, productRepo repositories.ProductRepository, variantRepo repositories.ProductVariantRepository, layerRepo repositories.CostLayerRepository, txManager repositories.TxManager, outbox *Outbox) StockMovementUseCase {
+	return &stockMovementUseCase{
+		repo:        repo,
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+		layerRepo:   layerRepo,
+		txManager:   txManager,
+		outbox:      outbox,
+	}
+}
+
+// heldStock finds the variant variantID names among the variants of product
+// and adds up all the stock the product holds. Products with variants keep
+// their stock by variant, so moving one of them takes a variantID.
+func (uc *stockMovementUseCase) heldStock(ctx context.Context, product *entities.Product, variantID *uint) (*entities.ProductVariant, uint, error) {
+	variants, err := uc.variantRepo.GetByProductIDs(ctx, []uint{product.ID})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var variant *entities.ProductVariant
+	held := product.Stock
+	for i := range variants {
+		held += variants[i].Stock
+		if variantID != nil && variants[i].ID == *variantID {
+			variant = &variants[i]
+		}
+	}
+	switch {
+	case variantID != nil && variant == nil:
+		return nil, 0, err_util.ErrVariantNotFound
+	case variantID == nil && len(variants) > 0:
+		return nil, 0, err_util.ErrVariantRequired
+	}
+	return variant, held, nil
+}
+
+func (uc *stockMovementUseCase) Record(ctx context.Context, productID uint, req *dto.StockMovementRequest) (*dto.StockMovementResponse, error) {
+	delta, err := entities.StockDelta(req.Type, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	var unitCost *money.Money
+	if req.UnitCost != nil {
+		if req.Type != entities.StockMovementReceipt {
+			return nil, err_util.ErrUnitCostNotAllowed
+		}
+		if !token.HasPermission(ctx, token.PermissionFinance) {
+			return nil, err_util.ErrForbidden
+		}
+		cost, err := inBaseCurrency(*req.UnitCost)
+		if err != nil {
+			return nil, err
+		}
+		unitCost = &cost
+	}
+
+	var movement *entities.StockMovement
+	err = uc.txManager.WithTransaction(ctx, func(ctx context.Context) error {
+		product, err := uc.productRepo.GetByIDForUpdate(ctx, productID)
+		if err != nil {
+			return err
+		}
+		variant, held, err := uc.heldStock(ctx, product, req.VariantID)
+		if err != nil {
+			return err
+		}
+
+		before := product.Stock
+		if variant != nil {
+			before = variant.Stock
+		}
+		stock := int64(before) + int64(delta)
+		if stock < 0 {
+			return err_util.ErrInsufficientStock
+		}
+
+		checkStockLevel, err := watchStockLevel(ctx, uc.productRepo, uc.outbox, productID)
+		if err != nil {
+			return err
+		}
+		if variant != nil {
+			err = uc.variantRepo.UpdateStock(ctx, variant.ID, uint(stock))
+		} else {
+			err = uc.productRepo.UpdateStock(ctx, productID, uint(stock))
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkStockLevel(ctx); err != nil {
+			return err
+		}
+		if unitCost != nil {
+			// The cost price covers the product as a whole, variants included
+			cost := entities.AverageCost(held, product.CostPrice, uint(delta), *unitCost)
+			if err := uc.productRepo.UpdateCostPrice(ctx, productID, cost); err != nil {
+				return err
+			}
+		}
+
+		movement = newStockMovement(ctx, productID, req.VariantID, req.Type, delta, uint(stock), req.Reason, req.Reference)
+		movement.UnitCost = unitCost
+		if err := uc.repo.Create(ctx, movement); err != nil {
+			return err
+		}
+		// Stock coming in without a unit cost of its own is taken at the
+		// cost of what is already on hand
+		return recordCostLayer(ctx, uc.layerRepo, movement, cmp.Or(unitCost, product.CostPrice))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.mapToResponse(ctx, movement), nil
+}
+
+func (uc *stockMovementUseCase) GetByProductID(ctx context.Context, productID uint, pagination *dto_base.PaginationRequest) (*dto.StockMovementListResponse, error) {
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	movements, totalData, err := uc.repo.GetByProductID(ctx, productID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, links, err := paginate(totalData, pagination, fmt.Sprintf("/api/v1/products/%d/stock-movements?page=", productID))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.StockMovementResponse, len(movements))
+	for i, m := range movements {
+		res[i] = *uc.mapToResponse(ctx, &m)
+	}
+
+	return &dto.StockMovementListResponse{
+		Data:       res,
+		Pagination: meta,
+		Links:      links,
+	}, nil
+}
+
+func (uc *stockMovementUseCase) Reconcile(ctx context.Context, productID uint) (*dto.StockReconciliationResponse, error) {
+	product, err := uc.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	variants, err := uc.variantRepo.GetByProductIDs(ctx, []uint{productID})
+	if err != nil {
+		return nil, err
+	}
+	held := product.Stock
+	for _, v := range variants {
+		held += v.Stock
+	}
+
+	ledger, err := uc.repo.SumByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToReconciliation(entities.StockLedgerBalance{
+		ProductID:   product.ID,
+		CachedStock: held,
+		LedgerStock: ledger,
+	}), nil
+}
+
+func (uc *stockMovementUseCase) GetDiscrepancies(ctx context.Context) ([]dto.StockReconciliationResponse, error) {
+	balances, err := uc.repo.GetDiscrepancies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.StockReconciliationResponse, len(balances))
+	for i, b := range balances {
+		res[i] = *mapToReconciliation(b)
+	}
+	return res, nil
+}
+
+// GetLowStock lists the products at or below their reorder point, most
+// urgent first.
+func (uc *stockMovementUseCase) GetLowStock(ctx context.Context, pagination *dto_base.PaginationRequest) (*dto.LowStockListResponse, error) {
+	levels, totalData, err := uc.productRepo.GetLowStock(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, links, err := paginate(totalData, pagination, "/api/v1/products/low-stock?page=")
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.LowStockResponse, len(levels))
+	for i, l := range levels {
+		res[i] = dto.LowStockResponse{
+			ProductID:       l.ProductID,
+			Name:            l.Name,
+			CategoryID:      l.CategoryID,
+			Stock:           l.Stock,
+			ReorderPoint:    *l.ReorderPoint,
+			ReorderQuantity: l.ReorderQuantity,
+			Shortfall:       *l.ReorderPoint - l.Stock,
+		}
+	}
+
+	return &dto.LowStockListResponse{
+		Data:       res,
+		Pagination: meta,
+		Links:      links,
+	}, nil
+}
+
+// mapToResponse shows the unit cost only to callers with the finance
+// permission.
+func (uc *stockMovementUseCase) mapToResponse(ctx context.Context, m *entities.StockMovement) *dto.StockMovementResponse {
+	res := &dto.StockMovementResponse{
+		ID:            m.ID,
+		ProductID:     m.ProductID,
+		VariantID:     m.VariantID,
+		Type:          m.Type,
+		Quantity:      m.Quantity,
+		StockAfter:    m.StockAfter,
+		Reason:        m.Reason,
+		Reference:     m.Reference,
+		AdminID:       m.AdminID.String(),
+		AdminUsername: m.AdminUsername,
+		CreatedAt:     m.CreatedAt,
+	}
+	if token.HasPermission(ctx, token.PermissionFinance) {
+		res.UnitCost = m.UnitCost
+	}
+	return res
+}
+
+func mapToReconciliation(b entities.StockLedgerBalance) *dto.StockReconciliationResponse {
+	difference := int64(b.CachedStock) - b.LedgerStock
+	return &dto.StockReconciliationResponse{
+		ProductID:   b.ProductID,
+		CachedStock: b.CachedStock,
+		LedgerStock: b.LedgerStock,
+		Difference:  difference,
+		Consistent:  difference == 0,
+	}
+}
+
+// newStockMovement builds a ledger entry attributed to the admin on ctx.
+func newStockMovement(ctx context.Context, productID uint, variantID *uint, movementType string, delta int, stockAfter uint, reason, reference string) *entities.StockMovement {
+	movement := &entities.StockMovement{
+		ProductID:  productID,
+		VariantID:  variantID,
+		Type:       movementType,
+		Quantity:   delta,
+		StockAfter: stockAfter,
+		Reason:     reason,
+		Reference:  reference,
+	}
+	if claims := token.ClaimsFromContext(ctx); claims != nil {
+		movement.AdminID = claims.ID
+		movement.AdminUsername = claims.Username
+	}
+	return movement
+}
@@ -0,0 +1,255 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	dto_products "product-manager/dto/products"
+	dto "product-manager/dto/taxes"
+	"product-manager/entities"
+	"product-manager/repositories"
+	err_util "product-manager/utils/error"
+	"product-manager/utils/money"
+)
+
+// TaxConfig holds the tax rules that apply to the whole catalog.
+// PRICES_INCLUDE_TAX says whether stored prices are gross, as shelf prices
+// usually are, or net.
+type TaxConfig struct {
+	PRICES_INCLUDE_TAX bool
+}
+
+type TaxClassUseCase interface {
+	Create(ctx context.Context, req *dto.TaxClassRequest) (*dto.TaxClassResponse, error)
+	GetAll(ctx context.Context) ([]dto.TaxClassResponse, error)
+	GetByID(ctx context.Context, id uint) (*dto.TaxClassResponse, error)
+	Update(ctx context.Context, id uint, req *dto.TaxClassRequest) (*dto.TaxClassResponse, error)
+	Delete(ctx context.Context, id uint) error
+	CreateRate(ctx context.Context, id uint, req *dto.TaxRateRequest) (*dto.TaxRateResponse, error)
+	GetRates(ctx context.Context, id uint) ([]dto.TaxRateResponse, error)
+	DeleteRate(ctx context.Context, id, rateID uint) error
+}
+
+type taxClassUseCase struct {
+	repo repositories.TaxRepository
+}
+
+func NewTaxClassUseCase(repo repositories.TaxRepository) TaxClassUseCase {
+	return &taxClassUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *taxClassUseCase) Create(ctx context.Context, req *dto.TaxClassRequest) (*dto.TaxClassResponse, error) {
+	class := &entities.TaxClass{}
+	if err := uc.applyRequest(ctx, class, req); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Create(ctx, class); err != nil {
+		return nil, err
+	}
+	return mapTaxClassToResponse(class, nil), nil
+}
+
+func (uc *taxClassUseCase) GetAll(ctx context.Context) ([]dto.TaxClassResponse, error) {
+	classes, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(classes))
+	for i, c := range classes {
+		ids[i] = c.ID
+	}
+	rates, err := uc.repo.GetRatesAt(ctx, ids, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[uint]*entities.TaxRate, len(rates))
+	for i := range rates {
+		current[rates[i].TaxClassID] = &rates[i]
+	}
+
+	res := make([]dto.TaxClassResponse, len(classes))
+	for i, c := range classes {
+		res[i] = *mapTaxClassToResponse(&c, current[c.ID])
+	}
+	return res, nil
+}
+
+func (uc *taxClassUseCase) GetByID(ctx context.Context, id uint) (*dto.TaxClassResponse, error) {
+	class, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := uc.repo.GetRates(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := mapTaxClassToResponse(class, rateAt(rates, time.Now()))
+	res.Rates = make([]dto.TaxRateResponse, len(rates))
+	for i, r := range rates {
+		res.Rates[i] = *mapTaxRateToResponse(&r)
+	}
+	return res, nil
+}
+
+func (uc *taxClassUseCase) Update(ctx context.Context, id uint, req *dto.TaxClassRequest) (*dto.TaxClassResponse, error) {
+	class, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.applyRequest(ctx, class, req); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Update(ctx, class); err != nil {
+		return nil, err
+	}
+	return uc.GetByID(ctx, id)
+}
+
+func (uc *taxClassUseCase) Delete(ctx context.Context, id uint) error {
+	return uc.repo.Delete(ctx, id)
+}
+
+func (uc *taxClassUseCase) CreateRate(ctx context.Context, id uint, req *dto.TaxRateRequest) (*dto.TaxRateResponse, error) {
+	if _, err := uc.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	rate := &entities.TaxRate{
+		TaxClassID:    id,
+		Rate:          *req.Rate,
+		EffectiveFrom: req.EffectiveFrom.Truncate(time.Microsecond),
+	}
+	if err := uc.repo.CreateRate(ctx, rate); err != nil {
+		return nil, err
+	}
+	return mapTaxRateToResponse(rate), nil
+}
+
+func (uc *taxClassUseCase) GetRates(ctx context.Context, id uint) ([]dto.TaxRateResponse, error) {
+	if _, err := uc.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	rates, err := uc.repo.GetRates(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.TaxRateResponse, len(rates))
+	for i, r := range rates {
+		res[i] = *mapTaxRateToResponse(&r)
+	}
+	return res, nil
+}
+
+// DeleteRate only removes rates still to come; a rate that has taken effect
+// stays as the record of what was charged and is superseded by adding a new
+// one instead.
+func (uc *taxClassUseCase) DeleteRate(ctx context.Context, id, rateID uint) error {
+	rate, err := uc.repo.GetRate(ctx, id, rateID)
+	if err != nil {
+		return err
+	}
+	if !rate.EffectiveFrom.After(time.Now()) {
+		return err_util.ErrTaxRateAlreadyEffective
+	}
+	return uc.repo.DeleteRate(ctx, rate.ID)
+}
+
+func (uc *taxClassUseCase) applyRequest(ctx context.Context, class *entities.TaxClass, req *dto.TaxClassRequest) error {
+	name := strings.TrimSpace(req.Name)
+	exists, err := uc.repo.ExistsByName(ctx, name, class.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return err_util.ErrTaxClassAlreadyExists
+	}
+
+	class.Name = name
+	class.Description = strings.TrimSpace(req.Description)
+	return nil
+}
+
+// rateAt picks the rate in effect at t from rates sorted oldest first.
+func rateAt(rates []entities.TaxRate, t time.Time) *entities.TaxRate {
+	var current *entities.TaxRate
+	for i := range rates {
+		if rates[i].EffectiveFrom.After(t) {
+			break
+		}
+		current = &rates[i]
+	}
+	return current
+}
+
+// applyTaxes splits the effective price of every product in res, and the
+// sale price of their variants, at the rate their tax class has now.
+func (uc *productUseCase) applyTaxes(ctx context.Context, res []dto_products.ProductResponse) error {
+	var ids []uint
+	for _, p := range res {
+		if p.TaxClassID != nil {
+			ids = append(ids, *p.TaxClassID)
+		}
+	}
+	rates, err := uc.taxRepo.GetRatesAt(ctx, ids, time.Now())
+	if err != nil {
+		return err
+	}
+	current := make(map[uint]uint, len(rates))
+	for _, r := range rates {
+		current[r.TaxClassID] = r.Rate
+	}
+
+	breakdown := func(price money.Money, rate uint) *dto_products.TaxBreakdown {
+		net, tax, gross := entities.ApplyTax(price, rate, uc.tax.PRICES_INCLUDE_TAX)
+		return &dto_products.TaxBreakdown{
+			Rate:             rate,
+			PricesIncludeTax: uc.tax.PRICES_INCLUDE_TAX,
+			Net:              net,
+			Tax:              tax,
+			Gross:            gross,
+		}
+	}
+
+	for i := range res {
+		p := &res[i]
+		var rate uint
+		if p.TaxClassID != nil {
+			rate = current[*p.TaxClassID]
+		}
+		p.Tax = breakdown(p.EffectivePrice, rate)
+		for j := range p.Variants {
+			p.Variants[j].Tax = breakdown(p.Variants[j].SalePrice, rate)
+		}
+	}
+	return nil
+}
+
+func mapTaxClassToResponse(c *entities.TaxClass, current *entities.TaxRate) *dto.TaxClassResponse {
+	res := &dto.TaxClassResponse{
+		ID:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+	if current != nil {
+		res.CurrentRate = mapTaxRateToResponse(current)
+	}
+	return res
+}
+
+func mapTaxRateToResponse(r *entities.TaxRate) *dto.TaxRateResponse {
+	return &dto.TaxRateResponse{
+		ID:            r.ID,
+		TaxClassID:    r.TaxClassID,
+		Rate:          r.Rate,
+		EffectiveFrom: r.EffectiveFrom,
+		CreatedAt:     r.CreatedAt,
+	}
+}
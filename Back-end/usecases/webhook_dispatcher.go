@@ -0,0 +1,242 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"product-manager/entities"
+	"product-manager/repositories"
+	"product-manager/utils/events"
+	"product-manager/utils/webhook"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	webhookMaxAttempts   = 8
+	webhookBaseBackoff   = 30 * time.Second
+	webhookMaxBackoff    = 6 * time.Hour
+	webhookTimeout       = 10 * time.Second
+	webhookLease         = 2 * time.Minute
+	webhookPollInterval  = 5 * time.Second
+	webhookBatchSize     = 20
+	webhookResponseLimit = 2048
+	webhookUserAgent     = "product-manager-webhooks/1"
+)
+
+// webhookEnvelope is the JSON body every webhook request carries.
+type webhookEnvelope struct {
+	ID         string    `json:"id"`
+	Event      string    `json:"event"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+// WebhookDispatcher turns events into deliveries for the subscriptions that
+// listen to them and sends those deliveries, retrying failures with
+// exponential backoff. Deliveries live in the database, so they survive
+// restarts and several instances can share the work.
+type WebhookDispatcher struct {
+	repo      repositories.WebhookRepository
+	txManager repositories.TxManager
+	client    *http.Client
+	wake      chan struct{}
+}
+
+func NewWebhookDispatcher(repo repositories.WebhookRepository, txManager repositories.TxManager) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		repo:      repo,
+		txManager: txManager,
+		client: &http.Client{
+			Timeout: webhookTimeout,
+			// A redirect is answered like any other non-2xx status; the
+			// signed request is never replayed against another host.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// Handle records a pending delivery of event for each active subscription
+// that lists it. As an outbox sink it writes them in the dispatch
+// transaction, so an event is fanned out exactly once.
+func (d *WebhookDispatcher) Handle(ctx context.Context, event events.Event) error {
+	subscriptions, err := d.repo.GetActiveByEvent(ctx, event.Name)
+	if err != nil || len(subscriptions) == 0 {
+		return err
+	}
+
+	payload, err := json.Marshal(webhookEnvelope{
+		ID:         event.ID,
+		Event:      event.Name,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s webhook: %w", event.Name, err)
+	}
+	eventID, err := uuid.Parse(event.ID)
+	if err != nil {
+		eventID = uuid.New()
+	}
+
+	now := time.Now()
+	deliveries := make([]entities.WebhookDelivery, len(subscriptions))
+	for i, s := range subscriptions {
+		deliveries[i] = entities.WebhookDelivery{
+			SubscriptionID: s.ID,
+			EventID:        eventID,
+			Event:          event.Name,
+			Payload:        string(payload),
+			Status:         entities.WebhookDeliveryPending,
+			NextAttemptAt:  &now,
+		}
+	}
+	if err := d.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return err
+	}
+	d.txManager.AfterCommit(ctx, d.Wake)
+	return nil
+}
+
+// Wake makes Run look for due deliveries now rather than at its next poll.
+func (d *WebhookDispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends due deliveries until ctx is done.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.deliverDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+func (d *WebhookDispatcher) deliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		deliveries, err := d.repo.ClaimDueDeliveries(ctx, webhookBatchSize, webhookLease)
+		if err != nil {
+			log.Printf("webhooks: %v", err)
+			return
+		}
+
+		var wg sync.WaitGroup
+		for i := range deliveries {
+			wg.Add(1)
+			go func(delivery *entities.WebhookDelivery) {
+				defer wg.Done()
+				d.deliver(ctx, delivery)
+			}(&deliveries[i])
+		}
+		wg.Wait()
+
+		if len(deliveries) < webhookBatchSize {
+			return
+		}
+	}
+}
+
+// deliver makes one attempt at delivery and schedules the next one when it
+// fails, until webhookMaxAttempts is reached.
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *entities.WebhookDelivery) {
+	delivery.Attempts++
+	attempt := &entities.WebhookAttempt{DeliveryID: delivery.ID, Attempt: delivery.Attempts}
+
+	started := time.Now()
+	status, body, err := d.send(ctx, delivery)
+	attempt.DurationMs = time.Since(started).Milliseconds()
+	attempt.ResponseBody = body
+
+	delivery.LastError = ""
+	delivery.LastStatusCode = nil
+	if status != 0 {
+		attempt.StatusCode = &status
+		delivery.LastStatusCode = &status
+	}
+	if err == nil && (status < 200 || status > 299) {
+		err = fmt.Errorf("receiver answered %d", status)
+	}
+
+	switch {
+	case err == nil:
+		delivery.Status = entities.WebhookDeliverySucceeded
+		delivery.NextAttemptAt = nil
+	case delivery.Attempts >= webhookMaxAttempts:
+		attempt.Error, delivery.LastError = err.Error(), err.Error()
+		delivery.Status = entities.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+	default:
+		attempt.Error, delivery.LastError = err.Error(), err.Error()
+		next := time.Now().Add(webhookBackoff(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+	}
+
+	// The outcome is recorded even when ctx is winding down, or the attempt
+	// would be repeated once the lease runs out
+	if err := d.repo.RecordAttempt(context.WithoutCancel(ctx), delivery, attempt); err != nil {
+		log.Printf("webhooks: %v", err)
+	}
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, delivery *entities.WebhookDelivery) (int, string, error) {
+	subscription := delivery.Subscription
+	if subscription == nil {
+		return 0, "", errors.New("subscription not loaded")
+	}
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set(webhook.HeaderEvent, delivery.Event)
+	req.Header.Set(webhook.HeaderEventID, delivery.EventID.String())
+	req.Header.Set(webhook.HeaderDelivery, fmt.Sprint(delivery.ID))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(subscription.Secret, time.Now(), body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer res.Body.Close()
+
+	// Only a snippet is kept for the log, cleaned up to fit a text column
+	snippet, _ := io.ReadAll(io.LimitReader(res.Body, webhookResponseLimit))
+	snippet = bytes.ReplaceAll(bytes.ToValidUTF8(snippet, nil), []byte{0}, nil)
+	return res.StatusCode, string(snippet), nil
+}
+
+// webhookBackoff doubles the wait after every failed attempt, starting at
+// webhookBaseBackoff and capped at webhookMaxBackoff, with up to a fifth
+// added at random so receivers coming back up are not hit all at once.
+func webhookBackoff(attempts int) time.Duration {
+	wait := webhookMaxBackoff
+	if attempts < 20 {
+		wait = min(webhookBaseBackoff<<(attempts-1), webhookMaxBackoff)
+	}
+	return wait + rand.N(wait/5+1)
+}
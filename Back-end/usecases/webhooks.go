@@ -0,0 +1,237 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	dto_base "product-manager/dto/base"
+	dto "product-manager/dto/webhooks"
+	"product-manager/entities"
+	"product-manager/repositories"
+)
+
+type WebhookUseCase interface {
+	Create(ctx context.Context, req *dto.WebhookRequest) (*dto.WebhookResponse, error)
+	GetAll(ctx context.Context) ([]dto.WebhookResponse, error)
+	GetByID(ctx context.Context, id uint) (*dto.WebhookResponse, error)
+	Update(ctx context.Context, id uint, req *dto.WebhookRequest) (*dto.WebhookResponse, error)
+	Delete(ctx context.Context, id uint) error
+	GetDeliveries(ctx context.Context, id uint, status string, pagination *dto_base.PaginationRequest) (*dto.WebhookDeliveryListResponse, error)
+	GetDelivery(ctx context.Context, id, deliveryID uint) (*dto.WebhookDeliveryResponse, error)
+	Redeliver(ctx context.Context, id, deliveryID uint) (*dto.WebhookDeliveryResponse, error)
+}
+
+type webhookUseCase struct {
+	repo       repositories.WebhookRepository
+	dispatcher *WebhookDispatcher
+}
+
+func NewWebhookUseCase(repo repositories.WebhookRepository, dispatcher *WebhookDispatcher) WebhookUseCase {
+	return &webhookUseCase{
+		repo:       repo,
+		dispatcher: dispatcher,
+	}
+}
+
+func (uc *webhookUseCase) Create(ctx context.Context, req *dto.WebhookRequest) (*dto.WebhookResponse, error) {
+	subscription := &entities.WebhookSubscription{Active: true}
+	if err := applyWebhookRequest(subscription, req); err != nil {
+		return nil, err
+	}
+	if subscription.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		subscription.Secret = secret
+	}
+
+	if err := uc.repo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	res := mapWebhookToResponse(subscription)
+	res.Secret = subscription.Secret
+	return res, nil
+}
+
+func (uc *webhookUseCase) GetAll(ctx context.Context) ([]dto.WebhookResponse, error) {
+	subscriptions, err := uc.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.WebhookResponse, len(subscriptions))
+	for i, s := range subscriptions {
+		res[i] = *mapWebhookToResponse(&s)
+	}
+	return res, nil
+}
+
+func (uc *webhookUseCase) GetByID(ctx context.Context, id uint) (*dto.WebhookResponse, error) {
+	subscription, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapWebhookToResponse(subscription), nil
+}
+
+func (uc *webhookUseCase) Update(ctx context.Context, id uint, req *dto.WebhookRequest) (*dto.WebhookResponse, error) {
+	subscription, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyWebhookRequest(subscription, req); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+	// Deliveries held back while the subscription was inactive may be due
+	uc.dispatcher.Wake()
+
+	res := mapWebhookToResponse(subscription)
+	if req.Secret != "" {
+		res.Secret = subscription.Secret
+	}
+	return res, nil
+}
+
+func (uc *webhookUseCase) Delete(ctx context.Context, id uint) error {
+	return uc.repo.Delete(ctx, id)
+}
+
+func (uc *webhookUseCase) GetDeliveries(ctx context.Context, id uint, status string, pagination *dto_base.PaginationRequest) (*dto.WebhookDeliveryListResponse, error) {
+	if _, err := uc.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	deliveries, totalData, err := uc.repo.GetDeliveries(ctx, id, status, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, links, err := paginate(totalData, pagination, fmt.Sprintf("/api/v1/webhooks/%d/deliveries?page=", id))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		res[i] = *mapDeliveryToResponse(&d)
+	}
+
+	return &dto.WebhookDeliveryListResponse{
+		Data:       res,
+		Pagination: meta,
+		Links:      links,
+	}, nil
+}
+
+// GetDelivery returns a delivery together with the log of its attempts.
+func (uc *webhookUseCase) GetDelivery(ctx context.Context, id, deliveryID uint) (*dto.WebhookDeliveryResponse, error) {
+	delivery, err := uc.repo.GetDelivery(ctx, id, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := uc.repo.GetAttempts(ctx, delivery.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := mapDeliveryToResponse(delivery)
+	res.AttemptLog = make([]dto.WebhookAttemptResponse, len(attempts))
+	for i, a := range attempts {
+		res.AttemptLog[i] = dto.WebhookAttemptResponse{
+			Attempt:      a.Attempt,
+			StatusCode:   a.StatusCode,
+			ResponseBody: a.ResponseBody,
+			Error:        a.Error,
+			DurationMs:   a.DurationMs,
+			CreatedAt:    a.CreatedAt,
+		}
+	}
+	return res, nil
+}
+
+// Redeliver sends a delivery's payload again as a new delivery with a fresh
+// attempt budget. The event ID stays the same so receivers can tell it is a
+// repeat.
+func (uc *webhookUseCase) Redeliver(ctx context.Context, id, deliveryID uint) (*dto.WebhookDeliveryResponse, error) {
+	original, err := uc.repo.GetDelivery(ctx, id, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	deliveries := []entities.WebhookDelivery{{
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		Event:          original.Event,
+		Payload:        original.Payload,
+		Status:         entities.WebhookDeliveryPending,
+		NextAttemptAt:  &now,
+	}}
+	if err := uc.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		return nil, err
+	}
+	uc.dispatcher.Wake()
+
+	return mapDeliveryToResponse(&deliveries[0]), nil
+}
+
+func applyWebhookRequest(s *entities.WebhookSubscription, req *dto.WebhookRequest) error {
+	s.URL = strings.TrimSpace(req.URL)
+	s.Events = entities.WebhookEvents(req.Events)
+	s.Description = strings.TrimSpace(req.Description)
+	if req.Secret != "" {
+		s.Secret = req.Secret
+	}
+	if req.Active != nil {
+		s.Active = *req.Active
+	}
+	return s.IsValid()
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+func mapWebhookToResponse(s *entities.WebhookSubscription) *dto.WebhookResponse {
+	return &dto.WebhookResponse{
+		ID:          s.ID,
+		URL:         s.URL,
+		Events:      s.Events,
+		Description: s.Description,
+		Active:      s.Active,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+func mapDeliveryToResponse(d *entities.WebhookDelivery) *dto.WebhookDeliveryResponse {
+	return &dto.WebhookDeliveryResponse{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID.String(),
+		Event:          d.Event,
+		Payload:        json.RawMessage(d.Payload),
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
@@ -11,8 +11,16 @@ var (
 	ErrPasswordMismatch      = errors.New(messages.PASSWORD_MISMATCH)
 
 	// Page errors
-	ErrPageNotFound = errors.New(messages.PAGE_NOT_FOUND)
-	ErrNotFound     = errors.New(messages.NOT_FOUND)
+	ErrPageNotFound  = errors.New(messages.PAGE_NOT_FOUND)
+	ErrNotFound      = errors.New(messages.NOT_FOUND)
+	ErrInvalidCursor = errors.New(messages.INVALID_CURSOR)
+	ErrInvalidFacet  = errors.New(messages.INVALID_FACET)
+
+	// Query errors
+	ErrInvalidSortField   = errors.New(messages.INVALID_SORT_FIELD)
+	ErrInvalidFilterField = errors.New(messages.INVALID_FILTER_FIELD)
+	ErrInvalidFilterOp    = errors.New(messages.INVALID_FILTER_OP)
+	ErrInvalidFilterValue = errors.New(messages.INVALID_FILTER_VALUE)
 
 	// Product errors
 	ErrProductNotFound         = errors.New(messages.PRODUCT_NOT_FOUND)
@@ -21,4 +29,95 @@ var (
 	ErrProductPriceRequired    = errors.New(messages.PRODUCT_PRICE_REQUIRED)
 	ErrInvalidProductID        = errors.New(messages.INVALID_PRODUCT_ID)
 	ErrProductAlreadyExists    = errors.New(messages.PRODUCT_ALREADY_EXISTS)
+	ErrProductNotInTrash       = errors.New(messages.PRODUCT_NOT_IN_TRASH)
+	ErrProductVersionConflict  = errors.New(messages.PRODUCT_VERSION_CONFLICT)
+	ErrBulkOperationRolledBack = errors.New(messages.BULK_OPERATION_ROLLED_BACK)
+
+	// Category errors
+	ErrCategoryNotFound      = errors.New(messages.CATEGORY_NOT_FOUND)
+	ErrCategoryNameRequired  = errors.New(messages.CATEGORY_NAME_REQUIRED)
+	ErrInvalidCategorySlug   = errors.New(messages.INVALID_CATEGORY_SLUG)
+	ErrInvalidCategoryID     = errors.New(messages.INVALID_CATEGORY_ID)
+	ErrCategoryAlreadyExists = errors.New(messages.CATEGORY_ALREADY_EXISTS)
+	ErrCategoryInUse         = errors.New(messages.CATEGORY_IN_USE)
+	ErrCategoryHasChildren   = errors.New(messages.CATEGORY_HAS_CHILDREN)
+	ErrCategoryCycle         = errors.New(messages.CATEGORY_CYCLE)
+
+	// Variant errors
+	ErrVariantNotFound            = errors.New(messages.VARIANT_NOT_FOUND)
+	ErrInvalidVariantID           = errors.New(messages.INVALID_VARIANT_ID)
+	ErrVariantSKURequired         = errors.New(messages.VARIANT_SKU_REQUIRED)
+	ErrVariantOptionsRequired     = errors.New(messages.VARIANT_OPTIONS_REQUIRED)
+	ErrVariantSKUAlreadyExists    = errors.New(messages.VARIANT_SKU_ALREADY_EXISTS)
+	ErrVariantOptionsAlreadyExist = errors.New(messages.VARIANT_OPTIONS_ALREADY_EXIST)
+	ErrVariantRequired            = errors.New(messages.VARIANT_REQUIRED)
+
+	// Image errors
+	ErrImageNotFound        = errors.New(messages.IMAGE_NOT_FOUND)
+	ErrInvalidImageID       = errors.New(messages.INVALID_IMAGE_ID)
+	ErrImageRequired        = errors.New(messages.IMAGE_REQUIRED)
+	ErrImageTooLarge        = errors.New(messages.IMAGE_TOO_LARGE)
+	ErrUnsupportedImageType = errors.New(messages.UNSUPPORTED_IMAGE_TYPE)
+	ErrInvalidImage         = errors.New(messages.INVALID_IMAGE)
+
+	// Import errors
+	ErrImportFileRequired  = errors.New(messages.IMPORT_FILE_REQUIRED)
+	ErrImportMissingColumn = errors.New(messages.IMPORT_MISSING_COLUMN)
+	ErrImportInvalidCSV    = errors.New(messages.IMPORT_INVALID_CSV)
+
+	// Stock errors
+	ErrInvalidStockMovementType = errors.New(messages.INVALID_STOCK_MOVEMENT_TYPE)
+	ErrInvalidStockQuantity     = errors.New(messages.INVALID_STOCK_QUANTITY)
+	ErrInsufficientStock        = errors.New(messages.INSUFFICIENT_STOCK)
+
+	// Price errors
+	ErrPriceNotFound         = errors.New(messages.PRICE_NOT_FOUND)
+	ErrInvalidPriceID        = errors.New(messages.INVALID_PRICE_ID)
+	ErrInvalidPriceDate      = errors.New(messages.INVALID_PRICE_DATE)
+	ErrPriceStartNotInFuture = errors.New(messages.PRICE_START_NOT_IN_FUTURE)
+	ErrInvalidPricePeriod    = errors.New(messages.INVALID_PRICE_PERIOD)
+	ErrPriceAlreadyEffective = errors.New(messages.PRICE_ALREADY_EFFECTIVE)
+
+	// Currency errors
+	ErrUnsupportedCurrency   = errors.New(messages.UNSUPPORTED_CURRENCY)
+	ErrInvalidExchangeRate   = errors.New(messages.INVALID_EXCHANGE_RATE)
+	ErrExchangeRateNotFound  = errors.New(messages.EXCHANGE_RATE_NOT_FOUND)
+	ErrSameCurrencyRate      = errors.New(messages.SAME_CURRENCY_RATE)
+	ErrCurrencyPriceNotFound = errors.New(messages.CURRENCY_PRICE_NOT_FOUND)
+	ErrBaseCurrencyPrice     = errors.New(messages.BASE_CURRENCY_PRICE)
+	ErrNotBaseCurrency       = errors.New(messages.NOT_BASE_CURRENCY)
+	ErrInvalidAmount         = errors.New(messages.INVALID_AMOUNT)
+
+	// Tax errors
+	ErrTaxClassNotFound        = errors.New(messages.TAX_CLASS_NOT_FOUND)
+	ErrInvalidTaxClassID       = errors.New(messages.INVALID_TAX_CLASS_ID)
+	ErrTaxClassAlreadyExists   = errors.New(messages.TAX_CLASS_ALREADY_EXISTS)
+	ErrTaxClassInUse           = errors.New(messages.TAX_CLASS_IN_USE)
+	ErrTaxRateNotFound         = errors.New(messages.TAX_RATE_NOT_FOUND)
+	ErrInvalidTaxRateID        = errors.New(messages.INVALID_TAX_RATE_ID)
+	ErrTaxRateAlreadyExists    = errors.New(messages.TAX_RATE_ALREADY_EXISTS)
+	ErrTaxRateAlreadyEffective = errors.New(messages.TAX_RATE_ALREADY_EFFECTIVE)
+
+	// Cost errors
+	ErrForbidden          = errors.New(messages.FORBIDDEN)
+	ErrUnitCostNotAllowed = errors.New(messages.UNIT_COST_NOT_ALLOWED)
+
+	ErrInvalidValuationMethod = errors.New(messages.INVALID_VALUATION_METHOD)
+
+	// Promotion errors
+	ErrPromotionNotFound          = errors.New(messages.PROMOTION_NOT_FOUND)
+	ErrInvalidPromotionID         = errors.New(messages.INVALID_PROMOTION_ID)
+	ErrInvalidPromotionValue      = errors.New(messages.INVALID_PROMOTION_VALUE)
+	ErrInvalidPromotionPeriod     = errors.New(messages.INVALID_PROMOTION_PERIOD)
+	ErrPromotionTargetsRequired   = errors.New(messages.PROMOTION_TARGETS_REQUIRED)
+	ErrPromotionTargetsNotAllowed = errors.New(messages.PROMOTION_TARGETS_NOT_ALLOWED)
+	ErrInvalidPromotionStatus     = errors.New(messages.INVALID_PROMOTION_STATUS)
+
+	// Webhook errors
+	ErrWebhookNotFound          = errors.New(messages.WEBHOOK_NOT_FOUND)
+	ErrInvalidWebhookID         = errors.New(messages.INVALID_WEBHOOK_ID)
+	ErrInvalidWebhookURL        = errors.New(messages.INVALID_WEBHOOK_URL)
+	ErrInvalidWebhookEvent      = errors.New(messages.INVALID_WEBHOOK_EVENT)
+	ErrWebhookDeliveryNotFound  = errors.New(messages.WEBHOOK_DELIVERY_NOT_FOUND)
+	ErrInvalidWebhookDeliveryID = errors.New(messages.INVALID_WEBHOOK_DELIVERY_ID)
 )
@@ -0,0 +1,76 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is something that happened, named like "product.created". ID is
+// unique per event so consumers can recognise repeats.
+type Event struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Payload    any       `json:"payload"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func New(name string, payload any) Event {
+	return Event{ID: uuid.NewString(), Name: name, Payload: payload, OccurredAt: time.Now()}
+}
+
+// Decode unmarshals the payload into v. Events dispatched from the outbox
+// carry their payload as JSON rather than the value it was recorded with.
+func (e Event) Decode(v any) error {
+	raw, ok := e.Payload.(json.RawMessage)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(e.Payload); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(raw, v)
+}
+
+type Handler func(ctx context.Context, event Event)
+
+// Bus delivers events to the handlers subscribed to their name, in the
+// order they subscribed. Delivery is synchronous, so handlers that do slow
+// work should hand it off.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish hands event to every subscribed handler. A handler that panics is
+// logged and skipped rather than failing the publisher.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	b.mu.RLock()
+	handlers := b.handlers[event.Name]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("event handler for %s panicked: %v", event.Name, r)
+				}
+			}()
+			handler(ctx, event)
+		}()
+	}
+}
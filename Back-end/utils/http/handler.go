@@ -13,7 +13,15 @@ func HandleErrorResponse(c echo.Context, code int, message string) error {
 		Status:  status.STATUS_FAILED,
 		Message: message,
 	})
-}	
+}
+
+func HandleErrorResponseWithData(c echo.Context, code int, message string, data any) error {
+	return c.JSON(code, &dto.BaseResponse{
+		Status:  status.STATUS_FAILED,
+		Message: message,
+		Data:    data,
+	})
+}
 
 func HandleSuccessResponse(c echo.Context, code int, message string, data any) error {
 	return c.JSON(code, &dto.BaseResponse{
@@ -0,0 +1,143 @@
+// Package money holds amounts tied to their currency. Every price and cost
+// the API takes or gives is a Money, whether it is in the base currency, as
+// catalog prices and costs are, or in another one, as prices set per
+// currency and the display prices of reads may be.
+package money
+
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	err_util "product-manager/utils/error"
+)
+
+// Currency is an ISO 4217 currency with the number of digits its minor unit
+// takes.
+type Currency struct {
+	Code     string
+	Exponent int
+}
+
+const (
+	IDR = "IDR"
+	USD = "USD"
+	SGD = "SGD"
+	EUR = "EUR"
+	JPY = "JPY"
+)
+
+// currencies lists the currencies prices can be kept in. Sen have long gone
+// out of use, so rupiah amounts are whole rupiah.
+var currencies = map[string]Currency{
+	IDR: {Code: IDR, Exponent: 0},
+	USD: {Code: USD, Exponent: 2},
+	SGD: {Code: SGD, Exponent: 2},
+	EUR: {Code: EUR, Exponent: 2},
+	JPY: {Code: JPY, Exponent: 0},
+}
+
+// Lookup finds a supported currency by its code, in any case.
+func Lookup(code string) (Currency, error) {
+	currency, ok := currencies[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %s", err_util.ErrUnsupportedCurrency, code)
+	}
+	return currency, nil
+}
+
+// Money is an amount in the minor unit of its currency, so 1234 USD is
+// $12.34. Requests leave the currency out to mean the base currency.
+type Money struct {
+	Amount   int64  `gorm:"type:bigint;not null" json:"amount" validate:"gt=0"`
+	Currency string `gorm:"type:char(3);not null" json:"currency"`
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Add sums m and n, which are in the same currency. A zero Money takes the
+// currency of what is added to it, so totals can start out empty.
+func (m Money) Add(n Money) Money {
+	return Money{Amount: m.Amount + n.Amount, Currency: cmp.Or(m.Currency, n.Currency)}
+}
+
+// Sub takes n, in the same currency, off m.
+func (m Money) Sub(n Money) Money {
+	return Money{Amount: m.Amount - n.Amount, Currency: cmp.Or(m.Currency, n.Currency)}
+}
+
+// UnmarshalParam reads a form or query field, which can only carry the
+// amount; the currency is left empty for the caller to settle.
+func (m *Money) UnmarshalParam(param string) error {
+	amount, err := strconv.ParseInt(strings.TrimSpace(param), 10, 64)
+	if err != nil {
+		return err_util.ErrInvalidAmount
+	}
+	*m = Money{Amount: amount}
+	return nil
+}
+
+// String formats m in major units, like "USD 12.34" or "IDR 150000".
+func (m Money) String() string {
+	currency, err := Lookup(m.Currency)
+	if err != nil || currency.Exponent == 0 {
+		return fmt.Sprintf("%s %d", m.Currency, m.Amount)
+	}
+	return fmt.Sprintf("%s %s", m.Currency, new(big.Rat).SetFrac(big.NewInt(m.Amount), pow10(currency.Exponent)).FloatString(currency.Exponent))
+}
+
+// ParseRate reads an exchange rate written as a positive decimal.
+func ParseRate(s string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok || rate.Sign() <= 0 {
+		return nil, err_util.ErrInvalidExchangeRate
+	}
+	return rate, nil
+}
+
+// FormatRate writes rate as a decimal with up to 10 places, the precision
+// rates are stored at.
+func FormatRate(rate *big.Rat) string {
+	s := rate.FloatString(10)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// Convert turns m into currency to at rate, the price of one major unit of
+// m's currency in major units of to. The result is rounded half up to the
+// minor unit of to.
+func Convert(m Money, to string, rate *big.Rat) (Money, error) {
+	from, err := Lookup(m.Currency)
+	if err != nil {
+		return Money{}, err
+	}
+	target, err := Lookup(to)
+	if err != nil {
+		return Money{}, err
+	}
+
+	amount := new(big.Rat).SetInt64(m.Amount)
+	amount.Mul(amount, rate)
+	amount.Mul(amount, new(big.Rat).SetFrac(pow10(target.Exponent), pow10(from.Exponent)))
+	return Money{Amount: RoundHalfUp(amount), Currency: target.Code}, nil
+}
+
+// RoundHalfUp rounds r to the nearest integer, taking halves away from zero.
+func RoundHalfUp(r *big.Rat) int64 {
+	num := new(big.Int).Abs(r.Num())
+	// (2|n| + d) / 2d is |n|/d + 1/2 rounded down
+	num.Mul(num, big.NewInt(2)).Add(num, r.Denom())
+	quo := num.Quo(num, new(big.Int).Mul(r.Denom(), big.NewInt(2)))
+	if r.Sign() < 0 {
+		quo.Neg(quo)
+	}
+	return quo.Int64()
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
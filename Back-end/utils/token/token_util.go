@@ -1,9 +1,11 @@
 package token
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,14 +18,23 @@ import (
 	http_util "product-manager/utils/http"
 )
 
+// PermissionFinance lets an admin see and change cost prices and read the
+// margin report.
+const PermissionFinance = "finance"
+
 type JWTClaim struct {
-	ID       uuid.UUID `json:"id"`
-	Username string    `json:"username"`
+	ID          uuid.UUID `json:"id"`
+	Username    string    `json:"username"`
+	Permissions []string  `json:"permissions,omitempty"`
 	jwt.RegisteredClaims
 }
 
+func (c *JWTClaim) HasPermission(permission string) bool {
+	return slices.Contains(c.Permissions, permission)
+}
+
 type TokenUtil interface {
-	GenerateToken(id uuid.UUID, username string) (string, error)
+	GenerateToken(id uuid.UUID, username string, permissions []string) (string, error)
 	GetClaims(c echo.Context) *JWTClaim
 }
 
@@ -33,10 +44,11 @@ func NewTokenUtil() TokenUtil {
 	return &tokenUtil{}
 }
 
-func (*tokenUtil) GenerateToken(id uuid.UUID, username string) (string, error) {
+func (*tokenUtil) GenerateToken(id uuid.UUID, username string, permissions []string) (string, error) {
 	claims := JWTClaim{
-		ID:       id,
-		Username: username,
+		ID:          id,
+		Username:    username,
+		Permissions: permissions,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(30 * 24 * time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -57,6 +69,80 @@ func (*tokenUtil) GetClaims(c echo.Context) *JWTClaim {
 	return claims
 }
 
+type claimsKey struct{}
+
+// ClaimsToContext copies the JWT claims set by echojwt onto the request
+// context so layers below the controller can attribute changes to an admin.
+func ClaimsToContext() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if user, ok := c.Get("user").(*jwt.Token); ok {
+				if claims, ok := user.Claims.(*JWTClaim); ok {
+					ctx := ContextWithClaims(c.Request().Context(), claims)
+					c.SetRequest(c.Request().WithContext(ctx))
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+func ContextWithClaims(ctx context.Context, claims *JWTClaim) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+func ClaimsFromContext(ctx context.Context) *JWTClaim {
+	claims, _ := ctx.Value(claimsKey{}).(*JWTClaim)
+	return claims
+}
+
+// RefreshPermissions narrows the permissions a token carries to those the
+// admin still holds, so revoking one takes effect on the next request rather
+// than when the token expires. Tokens without permissions skip the lookup;
+// a grant still takes effect on the next login. It runs after
+// ClaimsToContext.
+func RefreshPermissions(lookup func(ctx context.Context, id uuid.UUID) ([]string, error)) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			claims := ClaimsFromContext(ctx)
+			if claims == nil || len(claims.Permissions) == 0 {
+				return next(c)
+			}
+			held, err := lookup(ctx, claims.ID)
+			if err != nil {
+				return http_util.HandleErrorResponse(c, http.StatusInternalServerError, err.Error())
+			}
+
+			current := *claims
+			current.Permissions = slices.DeleteFunc(slices.Clone(claims.Permissions), func(p string) bool {
+				return !slices.Contains(held, p)
+			})
+			c.SetRequest(c.Request().WithContext(ContextWithClaims(ctx, &current)))
+			return next(c)
+		}
+	}
+}
+
+// HasPermission reports whether the admin behind ctx holds permission.
+func HasPermission(ctx context.Context, permission string) bool {
+	claims := ClaimsFromContext(ctx)
+	return claims != nil && claims.HasPermission(permission)
+}
+
+// RequirePermission turns away requests whose admin lacks permission. It
+// runs after ClaimsToContext and, on groups that use it, RefreshPermissions.
+func RequirePermission(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !HasPermission(c.Request().Context(), permission) {
+				return http_util.HandleErrorResponse(c, http.StatusForbidden, msg.FORBIDDEN)
+			}
+			return next(c)
+		}
+	}
+}
+
 func GetJWTConfig() echojwt.Config {
 	jwtKey := os.Getenv("JWT_KEY")
 	if jwtKey == "" {
@@ -69,7 +155,7 @@ func GetJWTConfig() echojwt.Config {
 		},
 		ErrorHandler: jwtErrorHandler,
 		SigningKey:   []byte(jwtKey),
-		TokenLookup: "header:Authorization:Bearer ",
+		TokenLookup:  "header:Authorization:Bearer ",
 	}
 }
 
@@ -78,33 +164,33 @@ func customTokenExtractor(c echo.Context) (string, error) {
 	if auth == "" {
 		return "", echojwt.ErrJWTMissing
 	}
-	
+
 	// Check if it starts with "Bearer "
 	if strings.HasPrefix(auth, "Bearer ") {
 		return strings.TrimPrefix(auth, "Bearer "), nil
 	}
-	
+
 	return auth, nil
 }
 
 func jwtErrorHandler(c echo.Context, err error) error {
 	c.Logger().Errorf("JWT error: %v", err)
-	
+
 	authHeader := c.Request().Header.Get("Authorization")
 	c.Logger().Errorf("Authorization header: %s", authHeader)
-	
+
 	if errors.Is(err, echojwt.ErrJWTMissing) {
 		return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.INVALID_TOKEN)
 	}
-	
+
 	if errors.Is(err, echojwt.ErrJWTInvalid) {
 		return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.INVALID_TOKEN)
 	}
-	
-	if strings.Contains(err.Error(), "token is malformed") || 
-	   strings.Contains(err.Error(), "could not base64 decode") {
+
+	if strings.Contains(err.Error(), "token is malformed") ||
+		strings.Contains(err.Error(), "could not base64 decode") {
 		return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.INVALID_TOKEN)
 	}
-	
+
 	return http_util.HandleErrorResponse(c, http.StatusUnauthorized, msg.UNAUTHORIZED)
-}
\ No newline at end of file
+}
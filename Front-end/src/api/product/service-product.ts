@@ -9,8 +9,14 @@ export interface ProductPayload {
   stock: number;
 }
 
-export interface ProductDetail extends ProductPayload {
+export interface Money {
+  amount: number;
+  currency: string;
+}
+
+export interface ProductDetail extends Omit<ProductPayload, "price"> {
   id: number;
+  price: Money;
   created_at: string;
   updated_at: string;
 }
@@ -90,7 +90,7 @@ const DeleteProductModal: React.FC<DeleteProductModalProps> = ({
                     <div className="flex justify-between">
                       <span className="text-sm font-medium text-gray-600">Harga:</span>
                       <span className="text-sm font-bold text-green-600">
-                        Rp {product.price.toLocaleString("id-ID")}
+                        Rp {product.price.amount.toLocaleString("id-ID")}
                       </span>
                     </div>
                     <div className="flex justify-between">
@@ -60,7 +60,7 @@ const EditProductModal: React.FC<EditProductModalProps> = ({
             defaultValues={{
               name: product.name,
               category: product.category,
-              price: product.price,
+              price: product.price.amount,
               stock: product.stock,
             }}
             onSubmit={handleSubmit}
@@ -19,11 +19,11 @@ import {
   AlertCircle,
   CheckCircle2
 } from "lucide-react";
-import type { ProductPayload } from "@/api/product/service-product";
+import type { ProductDetail } from "@/api/product/service-product";
 
 interface ProductViewModalProps {
   open: boolean;
-  product: ProductPayload | null;
+  product: ProductDetail | null;
   onClose: () => void;
 }
 
@@ -130,7 +130,7 @@ const ProductViewModal: React.FC<ProductViewModalProps> = ({
                     </div>
                   </div>
                   <p className="text-xl font-bold text-green-600">
-                    Rp {product.price.toLocaleString("id-ID")}
+                    Rp {product.price.amount.toLocaleString("id-ID")}
                   </p>
                 </div>
               </div>
@@ -174,7 +174,7 @@ const ProductViewModal: React.FC<ProductViewModalProps> = ({
                   <div>
                     <span className="text-gray-600">Total Nilai Stok:</span>
                     <p className="font-bold text-gray-800">
-                      Rp {(product.price * product.stock).toLocaleString("id-ID")}
+                      Rp {(product.price.amount * product.stock).toLocaleString("id-ID")}
                     </p>
                   </div>
                   <div>
@@ -76,7 +76,7 @@ const ProductTableRow: React.FC<ProductTableRowProps> = ({
       
       <TableCell className="py-6 px-6">
         <div className="text-lg font-bold text-gray-900">
-          Rp {product.price.toLocaleString("id-ID")}
+          Rp {product.price.amount.toLocaleString("id-ID")}
         </div>
         <div className="text-sm text-gray-500">Per unit</div>
       </TableCell>